@@ -0,0 +1,40 @@
+package pgforecast
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultConcurrency is the number of Open-Meteo requests made in parallel
+// when ForecastOptions.Concurrency is not set.
+const DefaultConcurrency = 4
+
+// SiteResult holds the outcome of forecasting one site in a multi-site run.
+type SiteResult struct {
+	Site     Site
+	Forecast *SiteForecast
+	Err      error
+}
+
+// GenerateForecasts forecasts several sites. Weather is fetched in batched
+// Open-Meteo requests (up to maxBatchLocations sites each), running at most
+// opts.Concurrency requests at a time. Results are returned in the same order
+// as sites; a failure for one site is recorded in its SiteResult and does not
+// stop the others. Sites not yet computed when ctx is cancelled get ctx.Err().
+func GenerateForecasts(ctx context.Context, sites []Site, opts ForecastOptions) []SiteResult {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	fetched := fetchWeatherBatch(ctx, sites, opts)
+
+	results := make([]SiteResult, len(sites))
+	for i, site := range sites {
+		results[i].Site = site
+		if err := fetched[i].err; err != nil {
+			results[i].Err = fmt.Errorf("fetching weather for %s: %w", site.Name, err)
+			continue
+		}
+		results[i].Forecast, results[i].Err = buildForecast(ctx, site, opts, fetched[i])
+	}
+	return results
+}
@@ -0,0 +1,123 @@
+package pgforecast
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchClient returns a mock client that answers multi-location requests with
+// one empty forecast per requested latitude, failing any request that
+// includes failLat.
+func batchClient(failLat string, calls *int, maxInFlight *int) *http.Client {
+	var mu sync.Mutex
+	inFlight := 0
+	return mockClient(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		*calls++
+		inFlight++
+		if inFlight > *maxInFlight {
+			*maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		lats := strings.Split(req.URL.Query().Get("latitude"), ",")
+		for _, l := range lats {
+			if l == failLat {
+				return nil, errors.New("boom")
+			}
+		}
+		parts := make([]string, len(lats))
+		for i := range lats {
+			parts[i] = `{"hourly":{"time":[]}}`
+		}
+		body := parts[0]
+		if len(parts) > 1 {
+			body = "[" + strings.Join(parts, ",") + "]"
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			Header:     make(http.Header),
+		}, nil
+	})
+}
+
+func TestGenerateForecasts(t *testing.T) {
+	calls, maxInFlight := 0, 0
+	client := batchClient("40.0000", &calls, &maxInFlight)
+
+	var sites []Site
+	for i := 0; i < 45; i++ {
+		sites = append(sites, Site{Name: fmt.Sprintf("S%02d", i), Lat: float64(i)})
+	}
+
+	results := GenerateForecasts(context.Background(), sites, ForecastOptions{HTTPClient: client, Concurrency: 2})
+	if len(results) != len(sites) {
+		t.Fatalf("got %d results, want %d", len(results), len(sites))
+	}
+	for i, r := range results {
+		if r.Site.Name != sites[i].Name {
+			t.Errorf("results[%d].Site = %q, want %q", i, r.Site.Name, sites[i].Name)
+		}
+		if i >= 40 {
+			// The last chunk (sites 40-44) contains the failing latitude.
+			if r.Err == nil {
+				t.Errorf("results[%d] should have failed", i)
+			}
+			continue
+		}
+		if r.Err != nil || r.Forecast == nil {
+			t.Errorf("results[%d]: err=%v forecast=%v", i, r.Err, r.Forecast)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("HTTP calls = %d, want 3 (chunks of %d)", calls, maxBatchLocations)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("max concurrent requests = %d, want <= 2", maxInFlight)
+	}
+}
+
+func TestGenerateForecastsCachesPerSite(t *testing.T) {
+	calls, maxInFlight := 0, 0
+	client := batchClient("", &calls, &maxInFlight)
+	opts := ForecastOptions{HTTPClient: client, Cache: NewFileCache(t.TempDir(), time.Hour)}
+	sites := []Site{{Name: "A", Lat: 1}, {Name: "B", Lat: 2}, {Name: "C", Lat: 3}}
+
+	GenerateForecasts(context.Background(), sites, opts)
+	if calls != 1 {
+		t.Fatalf("HTTP calls = %d, want 1", calls)
+	}
+
+	// Each site's slice of the batch response is reusable on its own.
+	f, err := GenerateForecast(sites[1], opts)
+	if err != nil {
+		t.Fatalf("GenerateForecast: %v", err)
+	}
+	if !f.FromCache || calls != 1 {
+		t.Errorf("FromCache = %v, calls = %d; want cached single-site hit", f.FromCache, calls)
+	}
+}
+
+func TestGenerateForecastsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := GenerateForecasts(ctx, []Site{{Name: "A"}, {Name: "B"}}, ForecastOptions{})
+	for _, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("%s: err = %v, want context.Canceled", r.Site.Name, r.Err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package pgforecast
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached Open-Meteo responses are reused by default.
+const DefaultCacheTTL = 15 * time.Minute
+
+// ResponseCache stores raw Open-Meteo responses keyed by request.
+// The key encodes everything that affects the response (location, requested
+// parameters, units and model), so callers can use it as an opaque string.
+type ResponseCache interface {
+	// Get returns a cached response and when it was fetched. ok is false when
+	// there is no usable (present and unexpired) entry.
+	Get(key string) (data []byte, fetchedAt time.Time, ok bool)
+	// Set stores a response fetched at the given time.
+	Set(key string, data []byte, fetchedAt time.Time) error
+}
+
+// FileCache is a ResponseCache backed by one file per response in a directory.
+// The file modification time records when the response was fetched.
+type FileCache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// NewFileCache returns a FileCache in dir whose entries expire after ttl.
+// A ttl <= 0 uses DefaultCacheTTL.
+func NewFileCache(dir string, ttl time.Duration) *FileCache {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &FileCache{Dir: dir, TTL: ttl}
+}
+
+// DefaultCacheDir returns the pgforecast directory under the user cache dir.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating user cache dir: %w", err)
+	}
+	return filepath.Join(dir, "pgforecast"), nil
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements ResponseCache.
+func (c *FileCache) Get(key string) ([]byte, time.Time, bool) {
+	p := c.path(key)
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	fetchedAt := info.ModTime()
+	if time.Since(fetchedAt) > c.TTL {
+		return nil, time.Time{}, false
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	return data, fetchedAt, true
+}
+
+// Set implements ResponseCache. The entry is written atomically.
+func (c *FileCache) Set(key string, data []byte, fetchedAt time.Time) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("creating cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.Dir, "*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating cache file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache file: %w", err)
+	}
+	if err := os.Chtimes(tmp.Name(), fetchedAt, fetchedAt); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("setting cache file time: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("renaming cache file: %w", err)
+	}
+	return nil
+}
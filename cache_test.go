@@ -0,0 +1,83 @@
+package pgforecast
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFileCache(t *testing.T) {
+	c := NewFileCache(t.TempDir(), time.Hour)
+
+	if _, _, ok := c.Get("missing"); ok {
+		t.Error("Get on empty cache returned ok")
+	}
+
+	fetchedAt := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	if err := c.Set("key", []byte(`{"a":1}`), fetchedAt); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	data, got, ok := c.Get("key")
+	if !ok {
+		t.Fatal("Get after Set returned !ok")
+	}
+	if string(data) != `{"a":1}` {
+		t.Errorf("data = %s", data)
+	}
+	if !got.Equal(fetchedAt) {
+		t.Errorf("fetchedAt = %v, want %v", got, fetchedAt)
+	}
+
+	if err := c.Set("old", []byte(`{}`), time.Now().Add(-2*time.Hour)); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if _, _, ok := c.Get("old"); ok {
+		t.Error("expired entry returned ok")
+	}
+}
+
+func TestFetchWeatherCache(t *testing.T) {
+	calls := 0
+	client := mockClient(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"hourly":{"time":["2026-02-19T08:00"]}}`))),
+			Header:     make(http.Header),
+		}, nil
+	})
+	opts := ForecastOptions{HTTPClient: client, Cache: NewFileCache(t.TempDir(), time.Hour)}
+	site := Site{Lat: 50.6, Lon: -2.3}
+
+	_, first, cached, err := fetchWeather(context.Background(), site, opts)
+	if err != nil || cached {
+		t.Fatalf("first fetch: cached=%v err=%v", cached, err)
+	}
+	_, second, cached, err := fetchWeather(context.Background(), site, opts)
+	if err != nil || !cached {
+		t.Fatalf("second fetch: cached=%v err=%v", cached, err)
+	}
+	if calls != 1 {
+		t.Errorf("HTTP calls = %d, want 1", calls)
+	}
+	if second.Sub(first).Abs() > time.Second {
+		t.Errorf("cached fetchedAt = %v, want ~%v", second, first)
+	}
+
+	// A different model is a different cache key.
+	opts.Model = "ecmwf"
+	if _, _, cached, _ := fetchWeather(context.Background(), site, opts); cached {
+		t.Error("different model should not hit the cache")
+	}
+
+	opts.RefreshCache = true
+	if _, _, cached, _ := fetchWeather(context.Background(), site, opts); cached {
+		t.Error("RefreshCache should bypass the cache")
+	}
+	if calls != 3 {
+		t.Errorf("HTTP calls = %d, want 3", calls)
+	}
+}
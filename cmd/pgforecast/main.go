@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/matt-FFFFFF/pgforecast"
+	"github.com/matt-FFFFFF/pgforecast/sounding"
+	"github.com/matt-FFFFFF/pgforecast/web"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -27,6 +36,20 @@ var (
 	days       int
 	timezone   string
 	model      string
+	models     string
+	noCache    bool
+	refresh    bool
+	cacheTTL   time.Duration
+	workers    int
+	addr       string
+	exportDir  string
+	dayOffset  int
+	hour       int
+	svgOut     string
+	emagram    bool
+	explain    bool
+	scorerName string
+	profiles   string
 )
 
 func main() {
@@ -34,15 +57,128 @@ func main() {
 		Use:   "pgforecast",
 		Short: "Paragliding forecast tool",
 		RunE:  run,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(profileList()) > 1 && cmd.HasParent() {
+				return fmt.Errorf("several --profile values are only supported when forecasting")
+			}
+			for _, name := range profileList() {
+				if _, err := pgforecast.ResolveProfile(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
 	}
 
 	pf := rootCmd.PersistentFlags()
 	pf.StringVarP(&cfgFile, "config", "c", "", "Path to config YAML file")
 	pf.StringVarP(&units, "units", "u", "mph", "Wind units: mph/kph/knots/ms")
 	pf.StringVar(&timezone, "timezone", "Europe/London", "Timezone")
-	pf.StringVar(&model, "model", "auto", "Weather model (auto/gfs/ecmwf/icon)")
+	pf.BoolVar(&noCache, "no-cache", false, "Always fetch from Open-Meteo without using the response cache")
+	pf.BoolVar(&refresh, "refresh", false, "Ignore cached responses but update the cache")
+	pf.DurationVar(&cacheTTL, "cache-ttl", pgforecast.DefaultCacheTTL, "How long cached Open-Meteo responses are reused")
+	pf.StringVar(&model, "model", pgforecast.ModelAuto, "Weather model ("+strings.Join(pgforecast.ModelNames(), "/")+")")
+	pf.StringVar(&scorerName, "scorer", pgforecast.ScorerDefault, "Scoring model ("+strings.Join(pgforecast.ScorerNames(), "/")+")")
+	pf.StringVar(&profiles, "profile", "", "Pilot profile ("+strings.Join(pgforecast.ProfileNames(), "/")+"); comma-separate several to compare scores side by side")
+
+	addSiteFlags(rootCmd)
+	rootCmd.Flags().BoolVar(&explain, "explain", false, "Show how each hour's flyability score was reached")
+
+	compareCmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare the forecast across several weather models",
+		RunE:  runCompare,
+	}
+	addSiteFlags(compareCmd)
+	compareCmd.Flags().StringVar(&models, "models", "ecmwf,icon,ukv", "Comma-separated weather models to compare")
+	rootCmd.AddCommand(compareCmd)
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve forecasts as a JSON REST API",
+		RunE:  runServe,
+	}
+	serveCmd.Flags().StringVarP(&sitesFile, "sites", "s", "", "Path to sites YAML file")
+	serveCmd.Flags().StringVar(&addr, "addr", ":8080", "Address to listen on")
+	serveCmd.Flags().IntVar(&days, "days", 3, "Default number of detailed forecast days")
+	rootCmd.AddCommand(serveCmd)
+
+	webCmd := &cobra.Command{
+		Use:   "web",
+		Short: "Serve the web frontend, with sites.json and tuning.json generated from config",
+		RunE:  runWeb,
+	}
+	webCmd.Flags().StringVarP(&sitesFile, "sites", "s", "", "Path to sites YAML file")
+	webCmd.Flags().StringVar(&addr, "addr", ":8080", "Address to listen on")
+	webCmd.Flags().StringVar(&exportDir, "export", "", "Write sites.json and tuning.json to this directory instead of serving")
+	rootCmd.AddCommand(webCmd)
+
+	soundingCmd := &cobra.Command{
+		Use:   "sounding",
+		Short: "Render a skew-T/emagram sounding for a site and hour as SVG",
+		RunE:  runSounding,
+	}
+	sf := soundingCmd.Flags()
+	sf.StringVarP(&sitesFile, "sites", "s", "", "Path to sites YAML file")
+	sf.StringVar(&siteName, "site", "", "Site name")
+	sf.StringVar(&latStr, "lat", "", "Latitude for ad-hoc site")
+	sf.StringVar(&lonStr, "lon", "", "Longitude for ad-hoc site")
+	sf.StringVar(&nameStr, "name", "", "Name for ad-hoc site")
+	sf.IntVar(&dayOffset, "day", 0, "Day to render (0 = today, 1 = tomorrow, ...)")
+	sf.IntVar(&hour, "hour", 13, "Local hour to render (0-23)")
+	sf.StringVarP(&svgOut, "out", "o", "sounding.svg", "SVG file to write (- for stdout)")
+	sf.BoolVar(&emagram, "emagram", false, "Draw an emagram instead of a skew-T")
+	rootCmd.AddCommand(soundingCmd)
+
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the tuning configuration",
+	}
+	configShowCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective tuning config as YAML, including a site's overrides",
+		RunE:  runConfigShow,
+	}
+	configShowCmd.Flags().StringVarP(&sitesFile, "sites", "s", "", "Path to sites YAML file")
+	configShowCmd.Flags().StringVar(&siteName, "site", "", "Show the config in effect for this site")
+	configCmd.AddCommand(configShowCmd)
+	configCheckCmd := &cobra.Command{
+		Use:          "check",
+		Short:        "Check the tuning config, and each site's overrides, for inconsistent values",
+		SilenceUsage: true,
+		RunE:         runConfigCheck,
+	}
+	configCheckCmd.Flags().StringVarP(&sitesFile, "sites", "s", "", "Also check each site's tuning overrides from this sites YAML file")
+	configCmd.AddCommand(configCheckCmd)
+	rootCmd.AddCommand(configCmd)
 
-	f := rootCmd.Flags()
+	sitesCmd := &cobra.Command{
+		Use:   "sites",
+		Short: "Inspect the sites file",
+	}
+	sitesValidateCmd := &cobra.Command{
+		Use:          "validate [sites.yaml]",
+		Short:        "Check a sites file, listing every problem with its line number",
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE:         runSitesValidate,
+	}
+	sitesValidateCmd.Flags().StringVarP(&sitesFile, "sites", "s", "", "Path to sites YAML file")
+	sitesCmd.AddCommand(sitesValidateCmd)
+	rootCmd.AddCommand(sitesCmd)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		stop()
+		os.Exit(1)
+	}
+}
+
+// addSiteFlags registers the site selection and output flags on a command.
+func addSiteFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
 	f.StringVarP(&sitesFile, "sites", "s", "", "Path to sites YAML file")
 	f.StringVar(&siteName, "site", "", "Filter to specific site name")
 	f.StringVar(&latStr, "lat", "", "Latitude for ad-hoc site")
@@ -53,16 +189,17 @@ func main() {
 	f.BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	f.StringVar(&outputFmt, "output", "text", "Output format: text or json")
 	f.IntVar(&days, "days", 3, "Number of detailed forecast days")
-
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
-	}
+	f.IntVar(&workers, "concurrency", pgforecast.DefaultConcurrency, "Number of Open-Meteo requests to run in parallel")
 }
 
-func run(cmd *cobra.Command, args []string) error {
-	tc, err := loadTuningConfig(cfgFile)
+// forecastOptions validates the shared flags and builds ForecastOptions.
+func forecastOptions(tc *pgforecast.TuningConfig) (pgforecast.ForecastOptions, error) {
+	if _, err := pgforecast.ResolveModel(model); err != nil {
+		return pgforecast.ForecastOptions{}, err
+	}
+	scorer, err := pgforecast.ResolveScorer(scorerName)
 	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+		return pgforecast.ForecastOptions{}, err
 	}
 
 	opts := pgforecast.ForecastOptions{
@@ -72,13 +209,27 @@ func run(cmd *cobra.Command, args []string) error {
 		Model:        model,
 		OutputFormat: "text",
 		Tuning:       tc,
+		Profile:      profileName(),
+		Scorer:       scorer,
+		Concurrency:  workers,
 	}
 	if jsonOutput || outputFmt == "json" {
 		opts.OutputFormat = "json"
 	}
+	if !noCache {
+		dir, err := pgforecast.DefaultCacheDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: response cache disabled: %v\n", err)
+		} else {
+			opts.Cache = pgforecast.NewFileCache(dir, cacheTTL)
+			opts.RefreshCache = refresh
+		}
+	}
+	return opts, nil
+}
 
-	var sites []pgforecast.Site
-
+// resolveSites returns the sites selected by the --lat/--lon or --sites/--site flags.
+func resolveSites() ([]pgforecast.Site, error) {
 	if latStr != "" || lonStr != "" {
 		lat, _ := strconv.ParseFloat(latStr, 64)
 		lon, _ := strconv.ParseFloat(lonStr, 64)
@@ -99,39 +250,412 @@ func run(cmd *cobra.Command, args []string) error {
 				s.WindMax, _ = strconv.Atoi(parts[1])
 			}
 		}
+		return []pgforecast.Site{s}, nil
+	}
+	if sitesFile == "" {
+		return nil, fmt.Errorf("specify --sites or --lat/--lon")
+	}
+	sites, err := pgforecast.LoadSites(sitesFile)
+	if err != nil {
+		return nil, err
+	}
+	if siteName != "" {
+		s, ok := pgforecast.FilterSite(sites, siteName)
+		if !ok {
+			return nil, fmt.Errorf("site %q not found", siteName)
+		}
 		sites = []pgforecast.Site{s}
-	} else if sitesFile != "" {
-		sites, err = pgforecast.LoadSites(sitesFile)
-		if err != nil {
-			return err
+	}
+	return sites, nil
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	tc, err := loadTuningConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	opts, err := forecastOptions(tc)
+	if err != nil {
+		return err
+	}
+
+	sites, err := resolveSites()
+	if err != nil {
+		return err
+	}
+
+	if names := profileList(); len(names) > 1 {
+		return runProfiles(cmd, sites, names, opts)
+	}
+
+	for _, r := range pgforecast.GenerateForecasts(cmd.Context(), sites, opts) {
+		if errors.Is(r.Err, context.Canceled) {
+			return r.Err
 		}
-		if siteName != "" {
-			s, ok := pgforecast.FilterSite(sites, siteName)
-			if !ok {
-				return fmt.Errorf("site %q not found", siteName)
+		if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", r.Err)
+			continue
+		}
+		if opts.OutputFormat == "json" {
+			pgforecast.FormatJSON(os.Stdout, r.Forecast, tc)
+		} else {
+			pgforecast.FormatText(os.Stdout, r.Forecast, tc)
+			if explain {
+				pgforecast.FormatScoreBreakdown(os.Stdout, r.Forecast)
 			}
-			sites = []pgforecast.Site{s}
 		}
-	} else {
-		return fmt.Errorf("specify --sites or --lat/--lon")
 	}
+	return nil
+}
 
+// runProfiles prints each site's scores for several pilot profiles side by side.
+func runProfiles(cmd *cobra.Command, sites []pgforecast.Site, names []string, opts pgforecast.ForecastOptions) error {
 	for _, site := range sites {
-		forecast, err := pgforecast.GenerateForecast(site, opts)
+		cmp, err := pgforecast.CompareProfilesWithContext(cmd.Context(), site, names, opts)
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", site.Name, err)
 			continue
 		}
 		if opts.OutputFormat == "json" {
-			pgforecast.FormatJSON(os.Stdout, forecast, tc)
+			pgforecast.FormatProfileComparisonJSON(os.Stdout, cmp)
 		} else {
-			pgforecast.FormatText(os.Stdout, forecast, tc)
+			pgforecast.FormatProfileComparisonText(os.Stdout, cmp)
+		}
+	}
+	return nil
+}
+
+// profileList splits the --profile flag into profile names.
+func profileList() []string {
+	var names []string
+	for _, name := range strings.Split(profiles, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// profileName returns the single --profile, or "" when none or several are
+// given.
+func profileName() string {
+	if names := profileList(); len(names) == 1 {
+		return names[0]
+	}
+	return ""
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	tc, err := loadTuningConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	opts, err := forecastOptions(tc)
+	if err != nil {
+		return err
+	}
+
+	sites, err := resolveSites()
+	if err != nil {
+		return err
+	}
+
+	modelList := strings.Split(models, ",")
+	for i := range modelList {
+		modelList[i] = strings.TrimSpace(modelList[i])
+	}
+
+	for _, site := range sites {
+		cmp, err := pgforecast.CompareModelsWithContext(cmd.Context(), site, modelList, opts)
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", site.Name, err)
+			continue
+		}
+		if opts.OutputFormat == "json" {
+			pgforecast.FormatComparisonJSON(os.Stdout, cmp)
+		} else {
+			pgforecast.FormatComparisonText(os.Stdout, cmp)
+		}
+	}
+	return nil
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	tc, err := loadTuningConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	opts, err := forecastOptions(tc)
+	if err != nil {
+		return err
+	}
+
+	var sites []pgforecast.Site
+	if sitesFile != "" {
+		if sites, err = pgforecast.LoadSites(sitesFile); err != nil {
+			return err
+		}
+	}
+
+	api := &pgforecast.Server{Sites: sites, Tuning: tc, Options: opts}
+	return listenAndServe(cmd.Context(), api.Handler())
+}
+
+func runWeb(cmd *cobra.Command, args []string) error {
+	tc, err := loadTuningConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if sitesFile == "" {
+		return fmt.Errorf("specify --sites")
+	}
+	sites, err := pgforecast.LoadSites(sitesFile)
+	if err != nil {
+		return err
+	}
+	// The browser applies site overrides itself, so it gets the profile
+	// baked into its starting tuning.
+	webTuning, err := pgforecast.ProfileTuning(pgforecast.Site{}, tc, profileName())
+	if err != nil {
+		return err
+	}
+
+	if exportDir != "" {
+		return web.WriteData(exportDir, sites, webTuning)
+	}
+
+	if !web.HasWASM() {
+		fmt.Fprintf(os.Stderr, "Warning: %s is not embedded; run 'go generate ./web' and rebuild\n", web.WASMFile)
+	}
+
+	opts, err := forecastOptions(tc)
+	if err != nil {
+		return err
+	}
+	api := &pgforecast.Server{Sites: sites, Tuning: tc, Options: opts}
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/", http.StripPrefix("/api", api.Handler()))
+	mux.Handle("/", web.Handler(sites, webTuning))
+	return listenAndServe(cmd.Context(), mux)
+}
+
+func runSounding(cmd *cobra.Command, args []string) error {
+	tc, err := loadTuningConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	opts, err := forecastOptions(tc)
+	if err != nil {
+		return err
+	}
+	if hour < 0 || hour > 23 || dayOffset < 0 || dayOffset > 15 {
+		return fmt.Errorf("--hour must be 0-23 and --day 0-15")
+	}
+
+	sites, err := resolveSites()
+	if err != nil {
+		return err
+	}
+	if len(sites) != 1 {
+		return fmt.Errorf("specify a single site with --site or --lat/--lon")
+	}
+	site := sites[0]
+	if tc, err = pgforecast.ProfileTuning(site, tc, opts.Profile); err != nil {
+		return err
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q", timezone)
+	}
+	now := time.Now().In(loc)
+	at := time.Date(now.Year(), now.Month(), now.Day()+dayOffset, hour, 0, 0, 0, loc)
+
+	data, err := pgforecast.FetchWeatherWithContext(cmd.Context(), site, opts)
+	if err != nil {
+		return err
+	}
+	var h *pgforecast.HourlyData
+	for i := range data {
+		if data[i].Time.Equal(at) {
+			h = &data[i]
+			break
 		}
 	}
+	if h == nil {
+		return fmt.Errorf("no forecast for %s at %s", site.Name, at.Format("2006-01-02 15:04 MST"))
+	}
+
+	snd, err := sounding.New(h, site, tc)
+	if err != nil {
+		return fmt.Errorf("%s at %s: %w", site.Name, at.Format("2006-01-02 15:04"), err)
+	}
+	svgOpts := sounding.Options{Emagram: emagram, Units: units, Location: loc}
+	if svgOut == "-" {
+		return snd.WriteSVG(os.Stdout, svgOpts)
+	}
+	f, err := os.Create(svgOut)
+	if err != nil {
+		return err
+	}
+	if err := snd.WriteSVG(f, svgOpts); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", svgOut)
 	return nil
 }
 
-// loadTuningConfig loads tuning config from file, env vars, merging with defaults.
+// listenAndServe serves handler on addr until ctx is cancelled, then shuts
+// the server down gracefully.
+func listenAndServe(ctx context.Context, handler http.Handler) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+	fmt.Fprintf(os.Stderr, "Listening on %s\n", addr)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func runSitesValidate(cmd *cobra.Command, args []string) error {
+	path := sitesFile
+	if len(args) == 1 {
+		path = args[0]
+	}
+	if path == "" {
+		return fmt.Errorf("specify a sites file")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading sites file: %w", err)
+	}
+	sites, problems, err := pgforecast.ParseSites(data)
+	if err != nil {
+		return fmt.Errorf("parsing sites YAML: %w", err)
+	}
+	for _, p := range problems {
+		fmt.Printf("%s: %s\n", path, p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s: %d problems", path, len(problems))
+	}
+	fmt.Printf("%s: %d sites OK\n", path, len(sites))
+	return nil
+}
+
+func runConfigCheck(cmd *cobra.Command, args []string) error {
+	problems := 0
+	report := func(prefix string, err error) error {
+		var tuningErr *pgforecast.TuningError
+		if !errors.As(err, &tuningErr) {
+			return err
+		}
+		for _, p := range tuningErr.Problems {
+			fmt.Printf("%s%s\n", prefix, p)
+		}
+		problems += len(tuningErr.Problems)
+		return nil
+	}
+
+	// Sites are only checked against a valid global config.
+	tc, err := loadTuningConfig(cfgFile)
+	if err == nil {
+		_, err = pgforecast.ProfileTuning(pgforecast.Site{}, tc, profileName())
+	}
+	if err != nil {
+		if err := report("", err); err != nil {
+			return err
+		}
+	} else if sitesFile != "" {
+		sites, err := pgforecast.LoadSites(sitesFile)
+		if err != nil {
+			return err
+		}
+		for _, site := range sites {
+			if _, err := pgforecast.ProfileTuning(site, tc, profileName()); err != nil {
+				if err := report(site.Name+": ", err); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if problems > 0 {
+		return fmt.Errorf("%d problems", problems)
+	}
+	fmt.Println("tuning config OK")
+	return nil
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	tc, err := loadTuningConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if siteName != "" {
+		if sitesFile == "" {
+			return fmt.Errorf("specify --sites with --site")
+		}
+		sites, err := pgforecast.LoadSites(sitesFile)
+		if err != nil {
+			return err
+		}
+		site, ok := pgforecast.FilterSite(sites, siteName)
+		if !ok {
+			return fmt.Errorf("site %q not found", siteName)
+		}
+		if tc, err = pgforecast.ProfileTuning(site, tc, profileName()); err != nil {
+			return err
+		}
+		if len(site.Tuning) > 0 {
+			fmt.Printf("# Tuning for %s, with its sites.yaml overrides applied\n", site.Name)
+		} else {
+			fmt.Printf("# Tuning for %s (no site overrides)\n", site.Name)
+		}
+	} else if tc, err = pgforecast.ProfileTuning(pgforecast.Site{}, tc, profileName()); err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(2)
+	defer enc.Close()
+	return enc.Encode(tc)
+}
+
+// loadTuningConfig loads tuning config from file, env vars, merging with
+// defaults, and validates the result. --profile is applied per site, over
+// any site overrides, by ProfileTuning.
 func loadTuningConfig(configPath string) (*pgforecast.TuningConfig, error) {
 	v := viper.New()
 	v.SetEnvPrefix("PGF")
@@ -154,11 +678,38 @@ func loadTuningConfig(configPath string) (*pgforecast.TuningConfig, error) {
 	v.SetDefault("thermal.cape_strong", def.Thermal.CAPEStrong)
 	v.SetDefault("thermal.cape_extreme", def.Thermal.CAPEExtreme)
 	v.SetDefault("thermal.lapse_rate_bonus", def.Thermal.LapseRateBonus)
+	v.SetDefault("thermal.stable_lapse_rate", def.Thermal.StableLapseRate)
+	v.SetDefault("thermal.inversion_min_c", def.Thermal.InversionMinC)
+	v.SetDefault("thermal.ceiling_weak_ft", def.Thermal.CeilingWeakFt)
+	v.SetDefault("thermal.ceiling_none_ft", def.Thermal.CeilingNoneFt)
+	v.SetDefault("thermal_strength.sensible_heat_fraction", def.ThermalStrength.SensibleHeatFraction)
+	v.SetDefault("thermal_strength.sink_rate_ms", def.ThermalStrength.SinkRateMS)
+	v.SetDefault("convection.cin_moderate", def.Convection.CINModerate)
+	v.SetDefault("convection.cin_strong", def.Convection.CINStrong)
+	v.SetDefault("convection.li_unstable", def.Convection.LIUnstable)
+	v.SetDefault("convection.li_stable", def.Convection.LIStable)
+	v.SetDefault("convection.shallow_boundary_layer_m", def.Convection.ShallowBoundaryLayerM)
+	v.SetDefault("convection.deep_boundary_layer_m", def.Convection.DeepBoundaryLayerM)
+	v.SetDefault("convection.min_sunshine_fraction", def.Convection.MinSunshineFraction)
+	v.SetDefault("storm.cape_moderate", def.Storm.CAPEModerate)
+	v.SetDefault("storm.cape_high", def.Storm.CAPEHigh)
+	v.SetDefault("storm.li_moderate", def.Storm.LIModerate)
+	v.SetDefault("storm.li_high", def.Storm.LIHigh)
+	v.SetDefault("storm.mid_level_rh", def.Storm.MidLevelRH)
+	v.SetDefault("storm.precip_prob", def.Storm.PrecipProb)
+	v.SetDefault("storm.shower_lookahead_hours", def.Storm.ShowerLookaheadHours)
+	v.SetDefault("storm.moderate_max_score", def.Storm.ModerateMaxScore)
+	v.SetDefault("storm.high_max_score", def.Storm.HighMaxScore)
+	v.SetDefault("warnings.low_cloudbase_ft", def.Warnings.LowCloudbaseFt)
+	v.SetDefault("warnings.low_visibility_m", def.Warnings.LowVisibilityM)
+	v.SetDefault("warnings.poor_visibility_m", def.Warnings.PoorVisibilityM)
+	v.SetDefault("warnings.rotor_angle", def.Warnings.RotorAngle)
 	v.SetDefault("orographic.min_wind_speed", def.Orographic.MinWindSpeed)
 	v.SetDefault("orographic.strong_angle", def.Orographic.StrongAngle)
 	v.SetDefault("orographic.moderate_angle", def.Orographic.ModerateAngle)
 	v.SetDefault("orographic.weak_angle", def.Orographic.WeakAngle)
 	v.SetDefault("cloudbase.min_realistic_ft", def.Cloudbase.MinRealisticFt)
+	v.SetDefault("cloudbase.thermal_trigger_c", def.Cloudbase.ThermalTriggerC)
 	v.SetDefault("scoring.base_score", def.Scoring.BaseScore)
 	v.SetDefault("scoring.wind_ideal_bonus", def.Scoring.WindIdealBonus)
 	v.SetDefault("scoring.wind_acceptable_bonus", def.Scoring.WindAcceptableBonus)
@@ -181,6 +732,10 @@ func loadTuningConfig(configPath string) (*pgforecast.TuningConfig, error) {
 	v.SetDefault("xc.epic_threshold", def.XC.EpicThreshold)
 	v.SetDefault("xc.high_threshold", def.XC.HighThreshold)
 	v.SetDefault("xc.medium_threshold", def.XC.MediumThreshold)
+	v.SetDefault("ensemble.wind_speed_spread", def.Ensemble.WindSpeedSpread)
+	v.SetDefault("ensemble.wind_dir_spread", def.Ensemble.WindDirSpread)
+	v.SetDefault("ensemble.score_spread", def.Ensemble.ScoreSpread)
+	v.SetDefault("ensemble.thermal_spread", def.Ensemble.ThermalSpread)
 
 	if configPath != "" {
 		v.SetConfigFile(configPath)
@@ -203,5 +758,8 @@ func loadTuningConfig(configPath string) (*pgforecast.TuningConfig, error) {
 	if err := v.Unmarshal(tc); err != nil {
 		return nil, fmt.Errorf("unmarshalling config: %w", err)
 	}
+	if err := tc.Validate(); err != nil {
+		return nil, err
+	}
 	return tc, nil
 }
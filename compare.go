@@ -0,0 +1,183 @@
+package pgforecast
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ModelComparison holds a forecast for one site run against several weather
+// models, aligned hour by hour.
+type ModelComparison struct {
+	Site      Site                  `json:"site"`
+	Generated time.Time             `json:"generated"`
+	Units     string                `json:"units"`
+	Models    []WeatherModel        `json:"models"`
+	Hours     []ModelHourComparison `json:"hours"`
+}
+
+// ModelHourMember holds one model's key metrics for a single hour.
+type ModelHourMember struct {
+	Model           string  `json:"model"`
+	WindSpeed       float64 `json:"wind_speed"`
+	WindDirection   float64 `json:"wind_direction"`
+	FlyabilityScore int     `json:"flyability_score"`
+	ThermalRating   string  `json:"thermal_rating"`
+}
+
+// ModelHourComparison reports agreement between models for one hour.
+type ModelHourComparison struct {
+	Time            time.Time         `json:"time"`
+	Members         []ModelHourMember `json:"members"`
+	WindSpeedMean   float64           `json:"wind_speed_mean"`
+	WindSpeedSpread float64           `json:"wind_speed_spread"`
+	WindDirMean     float64           `json:"wind_dir_mean"`
+	WindDirStr      string            `json:"wind_dir_str"`
+	WindDirSpread   float64           `json:"wind_dir_spread"`
+	ScoreMean       float64           `json:"score_mean"`
+	ScoreSpread     int               `json:"score_spread"`
+	ThermalSpread   int               `json:"thermal_spread"`
+	Confidence      string            `json:"confidence"` // High/Medium/Low
+}
+
+// CompareModels runs GenerateForecast for a site against each of the named
+// weather models and aligns the detailed hourly metrics by time.
+func CompareModels(site Site, models []string, opts ForecastOptions) (*ModelComparison, error) {
+	return CompareModelsWithContext(context.Background(), site, models, opts)
+}
+
+// CompareModelsWithContext is CompareModels with context support for cancellation.
+func CompareModelsWithContext(ctx context.Context, site Site, models []string, opts ForecastOptions) (*ModelComparison, error) {
+	if len(models) < 2 {
+		return nil, fmt.Errorf("comparison needs at least 2 models, got %d", len(models))
+	}
+
+	var forecasts []*SiteForecast
+	cmp := &ModelComparison{Site: site, Units: opts.Units}
+	for _, name := range models {
+		m, err := ResolveModel(name)
+		if err != nil {
+			return nil, err
+		}
+		mopts := opts
+		mopts.Model = m.Name
+		f, err := GenerateForecastWithContext(ctx, site, mopts)
+		if err != nil {
+			return nil, fmt.Errorf("model %s: %w", m.Name, err)
+		}
+		forecasts = append(forecasts, f)
+		cmp.Models = append(cmp.Models, m)
+		cmp.Generated = f.Generated
+	}
+
+	// Every forecast uses the site's tuning, so any one sets the thresholds.
+	cmp.Hours = compareForecasts(forecasts, forecasts[0].Tuning)
+	return cmp, nil
+}
+
+// compareForecasts aligns the detailed hours of several forecasts by time and
+// computes per-hour spread and confidence. Hours covered by fewer than two
+// forecasts are skipped.
+func compareForecasts(forecasts []*SiteForecast, tc *TuningConfig) []ModelHourComparison {
+	byTime := make(map[int64][]ModelHourMember)
+	times := make(map[int64]time.Time)
+	for _, f := range forecasts {
+		for _, day := range f.DetailedDays {
+			for _, h := range day.Hours {
+				key := h.Time.Unix()
+				times[key] = h.Time
+				byTime[key] = append(byTime[key], ModelHourMember{
+					Model:           f.Model.Name,
+					WindSpeed:       h.WindSpeed,
+					WindDirection:   h.WindDirection,
+					FlyabilityScore: h.FlyabilityScore,
+					ThermalRating:   h.ThermalRating,
+				})
+			}
+		}
+	}
+
+	keys := make([]int64, 0, len(byTime))
+	for k, members := range byTime {
+		if len(members) >= 2 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	hours := make([]ModelHourComparison, 0, len(keys))
+	for _, k := range keys {
+		hours = append(hours, compareHour(times[k], byTime[k], tc))
+	}
+	return hours
+}
+
+func compareHour(t time.Time, members []ModelHourMember, tc *TuningConfig) ModelHourComparison {
+	c := ModelHourComparison{Time: t, Members: members}
+
+	minSpeed, maxSpeed := math.Inf(1), math.Inf(-1)
+	minScore, maxScore := ScoreMax, ScoreMin
+	minThermal, maxThermal := thermalRank(ThermalExtreme), thermalRank(ThermalNone)
+	dirs := make([]float64, len(members))
+	var totalSpeed float64
+	totalScore := 0
+	for i, m := range members {
+		totalSpeed += m.WindSpeed
+		minSpeed = math.Min(minSpeed, m.WindSpeed)
+		maxSpeed = math.Max(maxSpeed, m.WindSpeed)
+		totalScore += m.FlyabilityScore
+		if m.FlyabilityScore < minScore {
+			minScore = m.FlyabilityScore
+		}
+		if m.FlyabilityScore > maxScore {
+			maxScore = m.FlyabilityScore
+		}
+		r := thermalRank(m.ThermalRating)
+		if r < minThermal {
+			minThermal = r
+		}
+		if r > maxThermal {
+			maxThermal = r
+		}
+		dirs[i] = m.WindDirection
+	}
+
+	n := float64(len(members))
+	c.WindSpeedMean = totalSpeed / n
+	c.WindSpeedSpread = maxSpeed - minSpeed
+	c.WindDirMean = circularMean(dirs, nil)
+	c.WindDirStr = DegreesToCompass(c.WindDirMean)
+	for i := range dirs {
+		for j := i + 1; j < len(dirs); j++ {
+			c.WindDirSpread = math.Max(c.WindDirSpread, angleDiff(dirs[i], dirs[j]))
+		}
+	}
+	c.ScoreMean = float64(totalScore) / n
+	c.ScoreSpread = maxScore - minScore
+	c.ThermalSpread = maxThermal - minThermal
+
+	disagreements := 0
+	if c.WindSpeedSpread > tc.Ensemble.WindSpeedSpread {
+		disagreements++
+	}
+	if c.WindDirSpread > tc.Ensemble.WindDirSpread {
+		disagreements++
+	}
+	if c.ScoreSpread > tc.Ensemble.ScoreSpread {
+		disagreements++
+	}
+	if c.ThermalSpread > tc.Ensemble.ThermalSpread {
+		disagreements++
+	}
+	switch {
+	case disagreements == 0:
+		c.Confidence = ConfidenceHigh
+	case disagreements == 1:
+		c.Confidence = ConfidenceMedium
+	default:
+		c.Confidence = ConfidenceLow
+	}
+	return c
+}
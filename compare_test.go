@@ -0,0 +1,105 @@
+package pgforecast
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareForecasts(t *testing.T) {
+	tc := DefaultTuningConfig()
+	t0 := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	t2 := t1.Add(time.Hour)
+
+	mk := func(model string, hours ...HourlyMetrics) *SiteForecast {
+		return &SiteForecast{
+			Model:        WeatherModel{Name: model},
+			DetailedDays: []DayForecast{{Hours: hours}},
+		}
+	}
+	forecasts := []*SiteForecast{
+		mk("ecmwf",
+			HourlyMetrics{Time: t0, WindSpeed: 12, WindDirection: 350, FlyabilityScore: 4, ThermalRating: ThermalModerate},
+			HourlyMetrics{Time: t1, WindSpeed: 10, WindDirection: 220, FlyabilityScore: 4, ThermalRating: ThermalWeak},
+			HourlyMetrics{Time: t2, WindSpeed: 10, WindDirection: 220, FlyabilityScore: 4, ThermalRating: ThermalWeak},
+		),
+		mk("icon",
+			HourlyMetrics{Time: t0, WindSpeed: 14, WindDirection: 10, FlyabilityScore: 4, ThermalRating: ThermalModerate},
+			HourlyMetrics{Time: t1, WindSpeed: 25, WindDirection: 40, FlyabilityScore: 1, ThermalRating: ThermalStrong},
+		),
+	}
+
+	hours := compareForecasts(forecasts, tc)
+	if len(hours) != 2 {
+		t.Fatalf("got %d hours, want 2 (t2 only has one model)", len(hours))
+	}
+
+	h := hours[0]
+	if !h.Time.Equal(t0) {
+		t.Errorf("hours[0].Time = %v, want %v", h.Time, t0)
+	}
+	if h.WindSpeedMean != 13 || h.WindSpeedSpread != 2 {
+		t.Errorf("wind mean/spread = %v/%v, want 13/2", h.WindSpeedMean, h.WindSpeedSpread)
+	}
+	if h.WindDirStr != "N" {
+		t.Errorf("WindDirStr = %q, want N (350° and 10° average across north)", h.WindDirStr)
+	}
+	if h.WindDirSpread != 20 {
+		t.Errorf("WindDirSpread = %v, want 20", h.WindDirSpread)
+	}
+	if h.Confidence != ConfidenceHigh {
+		t.Errorf("Confidence = %q, want High", h.Confidence)
+	}
+
+	h = hours[1]
+	if h.ScoreSpread != 3 || h.ThermalSpread != 2 {
+		t.Errorf("score/thermal spread = %d/%d, want 3/2", h.ScoreSpread, h.ThermalSpread)
+	}
+	if h.Confidence != ConfidenceLow {
+		t.Errorf("Confidence = %q, want Low", h.Confidence)
+	}
+}
+
+func TestCompareModelsNeedsTwoModels(t *testing.T) {
+	if _, err := CompareModels(Site{}, []string{"ecmwf"}, ForecastOptions{}); err == nil {
+		t.Error("expected error for a single model")
+	}
+}
+
+func TestCompareModelsSiteEnsembleOverride(t *testing.T) {
+	client := mockClient(func(req *http.Request) (*http.Response, error) {
+		speed := "10"
+		if req.URL.Query().Get("models") == "icon_seamless" {
+			speed = "18"
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(strings.NewReader(`{"hourly":{"time":["2026-06-01T12:00"],"is_day":[1],` +
+				`"wind_speed_10m":[` + speed + `],"wind_gusts_10m":[` + speed + `],"wind_direction_10m":[225]}}`)),
+			Header: make(http.Header),
+		}, nil
+	})
+	opts := ForecastOptions{HTTPClient: client, Timezone: "UTC"}
+	site := Site{Name: "Test", Lat: 50.6, Lon: -2.3, Aspect: 225, WindMin: 200, WindMax: 250}
+
+	// An 8 mph spread is above the default 6, so the models disagree...
+	cmp, err := CompareModels(site, []string{"ecmwf", "icon"}, opts)
+	if err != nil {
+		t.Fatalf("CompareModels: %v", err)
+	}
+	if len(cmp.Hours) != 1 || cmp.Hours[0].Confidence == ConfidenceHigh {
+		t.Fatalf("hours = %+v, want one hour below High confidence", cmp.Hours)
+	}
+
+	// ...unless the site tolerates a wider spread.
+	site.Tuning = TuningOverrides{"ensemble": map[string]any{"wind_speed_spread": 10}}
+	if cmp, err = CompareModels(site, []string{"ecmwf", "icon"}, opts); err != nil {
+		t.Fatalf("CompareModels: %v", err)
+	}
+	if cmp.Hours[0].Confidence != ConfidenceHigh {
+		t.Errorf("Confidence = %q, want High with the site's ensemble override", cmp.Hours[0].Confidence)
+	}
+}
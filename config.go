@@ -3,21 +3,262 @@ package pgforecast
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
-// LoadSites loads sites from a YAML file.
+// Bounds for a sane site elevation (m AMSL): from the shore of the Dead Sea
+// to the top of Everest.
+const (
+	MinSiteElevationM = -430
+	MaxSiteElevationM = 8849
+)
+
+// LoadSites loads sites from a YAML file. A file that parses but describes
+// impossible sites returns a *SitesError listing every problem.
 func LoadSites(path string) ([]Site, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("reading sites file: %w", err)
 	}
-	var cfg SitesConfig
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	sites, problems, err := ParseSites(data)
+	if err != nil {
 		return nil, fmt.Errorf("parsing sites YAML: %w", err)
 	}
-	return cfg.Sites, nil
+	if len(problems) > 0 {
+		return nil, &SitesError{Path: path, Problems: problems}
+	}
+	return sites, nil
+}
+
+// SiteProblem is one problem found in a sites file.
+type SiteProblem struct {
+	Line    int    `json:"line"`  // 1-based YAML line, 0 when unknown
+	Site    string `json:"site"`  // site name, when it has one
+	Field   string `json:"field"` // YAML path within the site, e.g. "launches[1].aspect"
+	Message string `json:"message"`
+}
+
+// String formats the problem as "line N: site: field: message".
+func (p SiteProblem) String() string {
+	var b strings.Builder
+	if p.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", p.Line)
+	}
+	if p.Site != "" {
+		b.WriteString(p.Site + ": ")
+	}
+	if p.Field != "" {
+		b.WriteString(p.Field + ": ")
+	}
+	b.WriteString(p.Message)
+	return b.String()
+}
+
+// SitesError lists every problem found in a sites file.
+type SitesError struct {
+	Path     string
+	Problems []SiteProblem
+}
+
+func (e *SitesError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = "  " + p.String()
+	}
+	return fmt.Sprintf("invalid sites file %s (%d problems):\n%s", e.Path, len(e.Problems), strings.Join(lines, "\n"))
+}
+
+// ParseSites parses a sites YAML document and validates every site,
+// returning the problems found with their YAML line numbers. The error is
+// only set when the YAML itself cannot be parsed.
+func ParseSites(data []byte) ([]Site, []SiteProblem, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	var cfg SitesConfig
+	if err := doc.Decode(&cfg); err != nil {
+		return nil, nil, err
+	}
+
+	var nodes []*yaml.Node
+	if len(doc.Content) > 0 {
+		if seq := mappingValue(doc.Content[0], "sites"); seq != nil && seq.Kind == yaml.SequenceNode {
+			nodes = seq.Content
+		}
+	}
+
+	v := siteValidator{seen: make(map[string]int)}
+	for i := range cfg.Sites {
+		node := &yaml.Node{}
+		if i < len(nodes) {
+			node = nodes[i]
+		}
+		v.validate(cfg.Sites[i], node)
+		normalizeLaunches(&cfg.Sites[i])
+	}
+	return cfg.Sites, v.problems, nil
+}
+
+// mappingValue returns the value node for key in a YAML mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// siteValidator collects the problems found across the sites in a file.
+type siteValidator struct {
+	problems []SiteProblem
+	seen     map[string]int // lower-cased site name to the line it was first defined on
+}
+
+// add records a problem with field in node, reported at the field's line
+// or, when the field is absent, the node's.
+func (v *siteValidator) add(node *yaml.Node, site, prefix, field, format string, args ...any) {
+	v.problems = append(v.problems, SiteProblem{Line: fieldLine(node, field), Site: site, Field: prefix + field, Message: fmt.Sprintf(format, args...)})
+}
+
+// fieldLine returns the line of field's value in a mapping node, or the
+// node's own line when the field is absent.
+func fieldLine(node *yaml.Node, field string) int {
+	if val := mappingValue(node, field); val != nil {
+		return val.Line
+	}
+	return node.Line
+}
+
+func (v *siteValidator) validate(s Site, node *yaml.Node) {
+	if s.Name == "" {
+		v.add(node, "", "", "name", SiteMissingMsg)
+	} else {
+		key := strings.ToLower(s.Name)
+		if first, ok := v.seen[key]; ok {
+			v.add(node, s.Name, "", "name", SiteDuplicateMsg, first)
+		} else {
+			v.seen[key] = fieldLine(node, "name")
+		}
+	}
+
+	for _, c := range []struct {
+		field    string
+		value    float64
+		min, max float64
+	}{
+		{"lat", s.Lat, -90, 90},
+		{"lon", s.Lon, -180, 180},
+	} {
+		switch {
+		case mappingValue(node, c.field) == nil:
+			v.add(node, s.Name, "", c.field, SiteMissingMsg)
+		case c.value < c.min || c.value > c.max:
+			v.add(node, s.Name, "", c.field, SiteRangeMsg, c.value, c.min, c.max)
+		}
+	}
+	v.validateElevation(s.Name, s.Elevation, node, "")
+
+	if len(s.Launches) == 0 {
+		v.validateLaunch(s.Name, Launch{WindMin: s.WindMin, WindMax: s.WindMax, BestDir: s.BestDir, Aspect: s.Aspect}, node, "")
+		return
+	}
+	for _, field := range []string{"wind_min", "wind_max", "best_dir", "aspect"} {
+		if mappingValue(node, field) != nil {
+			v.add(node, s.Name, "", field, SiteIgnoredMsg)
+		}
+	}
+	var launches []*yaml.Node
+	if seq := mappingValue(node, "launches"); seq != nil {
+		launches = seq.Content
+	}
+	for i, l := range s.Launches {
+		lnode := &yaml.Node{Line: fieldLine(node, "launches")}
+		if i < len(launches) {
+			lnode = launches[i]
+		}
+		prefix := fmt.Sprintf("launches[%d].", i)
+		v.validateElevation(s.Name, l.Elevation, lnode, prefix)
+		v.validateLaunch(s.Name, l, lnode, prefix)
+	}
+}
+
+func (v *siteValidator) validateElevation(site string, elev int, node *yaml.Node, prefix string) {
+	if elev < MinSiteElevationM || elev > MaxSiteElevationM {
+		v.add(node, site, prefix, "elevation", SiteRangeMsg, float64(elev), float64(MinSiteElevationM), float64(MaxSiteElevationM))
+	}
+}
+
+// validateLaunch checks a launch's directions are bearings and that the
+// aspect and best direction, when given, lie within its wind range.
+func (v *siteValidator) validateLaunch(site string, l Launch, node *yaml.Node, prefix string) {
+	valid := true
+	for _, d := range []struct {
+		field string
+		value int
+	}{
+		{"wind_min", l.WindMin},
+		{"wind_max", l.WindMax},
+		{"best_dir", l.BestDir},
+		{"aspect", l.Aspect},
+	} {
+		if d.value < 0 || d.value >= DegreesFullCircle {
+			v.add(node, site, prefix, d.field, SiteRangeMsg, float64(d.value), 0.0, float64(DegreesFullCircle-1))
+			valid = false
+		}
+	}
+	if !valid || mappingValue(node, "wind_min") == nil || mappingValue(node, "wind_max") == nil {
+		return
+	}
+	for _, d := range []struct {
+		field string
+		value int
+	}{
+		{"aspect", l.Aspect},
+		{"best_dir", l.BestDir},
+	} {
+		if mappingValue(node, d.field) != nil && !isInWindRange(float64(d.value), l.WindMin, l.WindMax) {
+			v.add(node, site, prefix, d.field, SiteOutsideWindRangeMsg, d.value, l.WindMin, l.WindMax)
+		}
+	}
+}
+
+// normalizeLaunches names unnamed launches after the way they face and fills
+// a multi-launch site's flat fields from its first launch, so code that only
+// reads the flat fields still sees a real launch. A site without launches
+// keeps its flat fields as the single-launch shorthand.
+func normalizeLaunches(s *Site) {
+	if len(s.Launches) == 0 {
+		return
+	}
+	for i := range s.Launches {
+		if s.Launches[i].Name == "" {
+			s.Launches[i].Name = DegreesToCompass(float64(s.Launches[i].Aspect))
+		}
+	}
+	first := s.Launches[0]
+	s.WindMin, s.WindMax, s.BestDir, s.Aspect = first.WindMin, first.WindMax, first.BestDir, first.Aspect
+	if s.Elevation == 0 {
+		s.Elevation = first.Elevation
+	}
+}
+
+// ForLaunch returns the site narrowed to one launch: the launch's direction
+// range, aspect and elevation (when set) replace the site's, and Launches is
+// cleared.
+func (s Site) ForLaunch(l Launch) Site {
+	s.WindMin, s.WindMax, s.BestDir, s.Aspect = l.WindMin, l.WindMax, l.BestDir, l.Aspect
+	if l.Elevation != 0 {
+		s.Elevation = l.Elevation
+	}
+	s.Launches = nil
+	return s
 }
 
 // FilterSite returns a single site by name, case-insensitive prefix match.
@@ -1,6 +1,7 @@
 package pgforecast
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -73,3 +74,147 @@ func TestLoadSitesInvalidYAML(t *testing.T) {
 		t.Error("expected error for invalid YAML")
 	}
 }
+
+func TestLoadSitesTuningOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sites.yaml")
+	yaml := `sites:
+  - name: Coastal
+    lat: 50.7
+    lon: -1.6
+    tuning:
+      wind:
+        ideal_min: 12
+      display:
+        gradient:
+          high:
+            icon: "🚫"
+  - name: Inland
+    lat: 50.8
+    lon: -2.3
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sites, err := LoadSites(path)
+	if err != nil {
+		t.Fatalf("LoadSites: %v", err)
+	}
+
+	tc, err := SiteTuning(sites[0], nil)
+	if err != nil {
+		t.Fatalf("SiteTuning: %v", err)
+	}
+	if tc.Wind.IdealMin != 12 || tc.Display.Gradient.High.Icon != "🚫" {
+		t.Errorf("overrides not applied: wind.ideal_min = %v, gradient icon = %q", tc.Wind.IdealMin, tc.Display.Gradient.High.Icon)
+	}
+	if len(sites[1].Tuning) != 0 {
+		t.Errorf("Inland tuning = %v, want none", sites[1].Tuning)
+	}
+}
+
+func TestLoadSitesLaunches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sites.yaml")
+	yaml := `sites:
+  - name: Two Way
+    lat: 50.9
+    lon: -2.2
+    elevation: 180
+    launches:
+      - wind_min: 20
+        wind_max: 70
+        best_dir: 45
+        aspect: 45
+      - name: West face
+        elevation: 150
+        wind_min: 240
+        wind_max: 290
+        best_dir: 265
+        aspect: 265
+  - name: Flat
+    lat: 50.8
+    lon: -2.3
+    wind_min: 210
+    wind_max: 240
+    aspect: 225
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sites, err := LoadSites(path)
+	if err != nil {
+		t.Fatalf("LoadSites: %v", err)
+	}
+
+	s := sites[0]
+	if len(s.Launches) != 2 || s.Launches[0].Name != "NE" {
+		t.Fatalf("launches = %+v, want 2 with the first named NE", s.Launches)
+	}
+	if s.WindMin != 20 || s.WindMax != 70 || s.Aspect != 45 || s.Elevation != 180 {
+		t.Errorf("flat fields = %d-%d aspect %d elev %d, want the first launch's 20-70 aspect 45 elev 180",
+			s.WindMin, s.WindMax, s.Aspect, s.Elevation)
+	}
+	if west := s.ForLaunch(s.Launches[1]); west.Aspect != 265 || west.Elevation != 150 || west.Launches != nil {
+		t.Errorf("ForLaunch(West face) = %+v", west)
+	}
+
+	if flat := sites[1]; len(flat.Launches) != 0 || flat.WindMin != 210 || flat.Aspect != 225 {
+		t.Errorf("single-launch shorthand changed: %+v", flat)
+	}
+}
+
+func TestParseSitesProblems(t *testing.T) {
+	yaml := `sites:
+  - name: Ringstead
+    lat: 500
+    lon: -2.3
+    wind_min: 400
+    wind_max: 260
+  - name: ringstead
+    lat: 50.6
+    lon: -2.3
+    elevation: 20000
+    wind_min: 210
+    wind_max: 260
+    aspect: 45
+  - lat: 50.8
+    launches:
+      - wind_min: 350
+        wind_max: 30
+        best_dir: 90
+`
+	_, problems, err := ParseSites([]byte(yaml))
+	if err != nil {
+		t.Fatalf("ParseSites: %v", err)
+	}
+
+	want := []struct {
+		line  int
+		field string
+	}{
+		{3, "lat"},
+		{5, "wind_min"},
+		{7, "name"},
+		{10, "elevation"},
+		{13, "aspect"},
+		{14, "name"},
+		{14, "lon"},
+		{18, "launches[0].best_dir"},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("got %d problems, want %d: %v", len(problems), len(want), problems)
+	}
+	for i, w := range want {
+		if problems[i].Line != w.line || problems[i].Field != w.field {
+			t.Errorf("problem %d = %v, want %s on line %d", i, problems[i], w.field, w.line)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "sites.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var sitesErr *SitesError
+	if _, err := LoadSites(path); !errors.As(err, &sitesErr) || len(sitesErr.Problems) != len(want) {
+		t.Errorf("LoadSites err = %v, want a SitesError with %d problems", err, len(want))
+	}
+}
@@ -1,33 +1,62 @@
 package pgforecast
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"sort"
 	"time"
 )
 
 // GenerateForecast fetches weather and computes metrics for a site.
 func GenerateForecast(site Site, opts ForecastOptions) (*SiteForecast, error) {
+	return GenerateForecastWithContext(context.Background(), site, opts)
+}
+
+// GenerateForecastWithContext fetches weather and computes metrics for a site,
+// stopping early with ctx.Err() if the context is cancelled.
+func GenerateForecastWithContext(ctx context.Context, site Site, opts ForecastOptions) (*SiteForecast, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	hourlyData, fetchedAt, fromCache, err := fetchWeather(ctx, site, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetching weather for %s: %w", site.Name, err)
+	}
+	return buildForecast(ctx, site, opts, fetchResult{data: hourlyData, fetchedAt: fetchedAt, fromCache: fromCache})
+}
+
+// buildForecast computes the detailed and extended forecast for a site from
+// already-fetched weather data.
+func buildForecast(ctx context.Context, site Site, opts ForecastOptions, fetched fetchResult) (*SiteForecast, error) {
 	loc, err := time.LoadLocation(opts.Timezone)
 	if err != nil {
 		loc = time.UTC
 	}
 
-	tc := opts.Tuning
-	if tc == nil {
-		tc = DefaultTuningConfig()
+	tc, err := ProfileTuning(site, opts.Tuning, opts.Profile)
+	if err != nil {
+		return nil, err
 	}
 
-	hourlyData, err := FetchWeather(site, opts)
+	model, err := ResolveModel(opts.Model)
 	if err != nil {
-		return nil, fmt.Errorf("fetching weather for %s: %w", site.Name, err)
+		return nil, err
+	}
+
+	scorer := opts.Scorer
+	if scorer == nil {
+		scorer = DefaultScorer{}
 	}
 
-	now := time.Now().In(loc)
+	hourlyData := fetched.data
 	forecast := &SiteForecast{
 		Site:      site,
-		Generated: now,
+		Generated: fetched.fetchedAt.In(loc),
+		FromCache: fetched.fromCache,
 		Units:     opts.Units,
+		Model:     model,
+		Tuning:    tc,
 	}
 
 	// Group hourly data by day (in local timezone)
@@ -47,10 +76,15 @@ func GenerateForecast(site Site, opts ForecastOptions) (*SiteForecast, error) {
 		detailedDays = 3
 	}
 
-	bestScore := 0
+	// The best window is ranked on the unrounded score, so a 3.4 beats a 2.6
+	// even though both show three stars.
+	bestExact, bestScore := 0.0, 0
 	bestWindow := ""
 
 	for dayIdx, dateStr := range dayOrder {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		indices := dayMap[dateStr]
 		date, _ := time.ParseInLocation("2006-01-02", dateStr, loc)
 
@@ -61,12 +95,12 @@ func GenerateForecast(site Site, opts ForecastOptions) (*SiteForecast, error) {
 				h := &hourlyData[idx]
 				lt := h.Time.In(loc)
 				if h.IsDay == 1 {
-					m := ComputeHourlyMetrics(h, site, tc)
+					m := ComputeHourlyMetricsWithScorer(h, site, tc, scorer, opts.Units)
 					m.Time = lt
 					df.Hours = append(df.Hours, m)
 					dayMetrics = append(dayMetrics, m)
-					if m.FlyabilityScore > bestScore {
-						bestScore = m.FlyabilityScore
+					if m.FlyabilityExact > bestExact {
+						bestExact, bestScore = m.FlyabilityExact, m.FlyabilityScore
 						bestWindow = lt.Format("Mon 15:04")
 					}
 				}
@@ -79,7 +113,7 @@ func GenerateForecast(site Site, opts ForecastOptions) (*SiteForecast, error) {
 				h := &hourlyData[idx]
 				lt := h.Time.In(loc)
 				if h.IsDay == 1 {
-					m := ComputeHourlyMetrics(h, site, tc)
+					m := ComputeHourlyMetricsWithScorer(h, site, tc, scorer, opts.Units)
 					m.Time = lt
 					dayMetrics = append(dayMetrics, m)
 				}
@@ -103,17 +137,22 @@ func summarizeDay(date time.Time, metrics []HourlyMetrics, tc *TuningConfig) Day
 		return DaySummary{Date: date}
 	}
 
-	var totalWind, totalDir, maxGusts, maxPrecip, maxCAPE float64
-	bestThermal := "None"
-	thermalOrder := map[string]int{"None": 0, "Weak": 1, "Moderate": 2, "Strong": 3, "Extreme": 4}
-	totalCloudbase := 0
+	var totalWind, maxGusts, maxPrecip, maxCAPE, maxClimb float64
+	bestThermal := ThermalNone
+	worstStorm := StormNone
+	totalCloudbase, totalThermalTop := 0, 0
 
 	// Collect all scores for top-3 averaging
-	var scores []int
+	var scores []float64
+	// Wind direction is averaged as speed-weighted vectors so that, e.g.,
+	// 350° and 10° average to N rather than S, and calm hours barely count.
+	dirs := make([]float64, 0, len(metrics))
+	speeds := make([]float64, 0, len(metrics))
 
 	for _, m := range metrics {
 		totalWind += m.WindSpeed
-		totalDir += m.WindDirection
+		dirs = append(dirs, m.WindDirection)
+		speeds = append(speeds, m.WindSpeed)
 		if m.WindGusts > maxGusts {
 			maxGusts = m.WindGusts
 		}
@@ -123,37 +162,55 @@ func summarizeDay(date time.Time, metrics []HourlyMetrics, tc *TuningConfig) Day
 		if m.CAPE > maxCAPE {
 			maxCAPE = m.CAPE
 		}
-		scores = append(scores, m.FlyabilityScore)
-		if thermalOrder[m.ThermalRating] > thermalOrder[bestThermal] {
+		if m.ThermalStrengthMS > maxClimb {
+			maxClimb = m.ThermalStrengthMS
+		}
+		scores = append(scores, m.FlyabilityExact)
+		if thermalRank(m.ThermalRating) > thermalRank(bestThermal) {
 			bestThermal = m.ThermalRating
 		}
+		if stormRank(m.StormRisk) > stormRank(worstStorm) {
+			worstStorm = m.StormRisk
+		}
 		totalCloudbase += m.CloudbaseFt
+		totalThermalTop += m.ThermalTopFt
 	}
 
 	// Day score = average of top 3 hours (not single best)
-	sort.Sort(sort.Reverse(sort.IntSlice(scores)))
+	sort.Sort(sort.Reverse(sort.Float64Slice(scores)))
 	topN := 3
 	if len(scores) < topN {
 		topN = len(scores)
 	}
-	sum := 0
+	sum := 0.0
 	for i := 0; i < topN; i++ {
 		sum += scores[i]
 	}
-	dayScore := (sum + topN/2) / topN // rounded integer average
+	dayScore := sum / float64(topN)
+
+	overdevelopment := ""
+	if onset, ok := OverdevelopmentOnset(metrics, tc); ok {
+		overdevelopment = onset.Format("15:04")
+	}
 
 	n := float64(len(metrics))
-	avgDir := totalDir / n
+	avgDir, dirVariability := CircularStats(dirs, speeds)
 	return DaySummary{
-		Date:          date,
-		AvgWindSpeed:  totalWind / n,
-		AvgWindDir:    avgDir,
-		WindDirStr:    DegreesToCompass(avgDir),
-		MaxGusts:      maxGusts,
-		ThermalRating: bestThermal,
-		MaxPrecipProb: maxPrecip,
-		AvgCloudbase:  totalCloudbase / len(metrics),
-		BestScore:     dayScore,
-		XCPotential:   CalcXCPotential(maxCAPE, totalCloudbase/len(metrics), totalWind/n, bestThermal, tc),
+		Date:                 date,
+		AvgWindSpeed:         totalWind / n,
+		AvgWindDir:           avgDir,
+		WindDirStr:           DegreesToCompass(avgDir),
+		WindDirVariability:   dirVariability,
+		MaxGusts:             maxGusts,
+		ThermalRating:        bestThermal,
+		MaxThermalStrength:   maxClimb,
+		MaxPrecipProb:        maxPrecip,
+		StormRisk:            worstStorm,
+		OverdevelopmentAfter: overdevelopment,
+		AvgCloudbase:         totalCloudbase / len(metrics),
+		AvgThermalTop:        totalThermalTop / len(metrics),
+		BestScore:            int(math.Round(dayScore)),
+		BestScoreExact:       dayScore,
+		XCPotential:          CalcXCPotential(maxCAPE, totalThermalTop/len(metrics), totalWind/n, bestThermal, tc),
 	}
 }
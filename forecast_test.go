@@ -0,0 +1,160 @@
+package pgforecast
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummarizeDayWindDirection(t *testing.T) {
+	tc := DefaultTuningConfig()
+	metrics := []HourlyMetrics{
+		{WindSpeed: 12, WindDirection: 350, FlyabilityScore: 3},
+		{WindSpeed: 12, WindDirection: 10, FlyabilityScore: 3},
+		{WindSpeed: 1, WindDirection: 180, FlyabilityScore: 3}, // near-calm, barely counts
+	}
+	s := summarizeDay(time.Now(), metrics, tc)
+	if s.WindDirStr != "N" {
+		t.Errorf("WindDirStr = %q (%.0f°), want N", s.WindDirStr, s.AvgWindDir)
+	}
+	if s.WindDirVariability <= 0 || s.WindDirVariability > WindDirVariableThreshold {
+		t.Errorf("WindDirVariability = %.1f, want small and positive", s.WindDirVariability)
+	}
+
+	veering := []HourlyMetrics{
+		{WindSpeed: 10, WindDirection: 90},
+		{WindSpeed: 10, WindDirection: 180},
+		{WindSpeed: 10, WindDirection: 270},
+	}
+	s = summarizeDay(time.Now(), veering, tc)
+	if s.WindDirVariability <= WindDirVariableThreshold {
+		t.Errorf("WindDirVariability = %.1f, want > %v for a veering day", s.WindDirVariability, WindDirVariableThreshold)
+	}
+}
+
+func TestSummarizeDayOverdevelopment(t *testing.T) {
+	tc := DefaultTuningConfig()
+	day := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	metrics := []HourlyMetrics{
+		{Time: at(10), StormRisk: StormNone, FlyabilityScore: 4},
+		{Time: at(12), StormRisk: StormLow, FlyabilityScore: 4},
+		{Time: at(14), StormRisk: StormModerate, FlyabilityScore: 2},
+		{Time: at(16), StormRisk: StormHigh, FlyabilityScore: 1},
+	}
+	s := summarizeDay(day, metrics, tc)
+	if s.OverdevelopmentAfter != "14:00" {
+		t.Errorf("OverdevelopmentAfter = %q, want 14:00", s.OverdevelopmentAfter)
+	}
+	if s.StormRisk != StormHigh {
+		t.Errorf("StormRisk = %q, want High", s.StormRisk)
+	}
+
+	s = summarizeDay(day, metrics[:2], tc)
+	if s.OverdevelopmentAfter != "" || s.StormRisk != StormLow {
+		t.Errorf("got %q / %q, want no overdevelopment and Low risk", s.OverdevelopmentAfter, s.StormRisk)
+	}
+
+	// A shower at 13:00 in unstable air brings the warning forward to 11:00.
+	showers := append([]HourlyMetrics{}, metrics...)
+	showers = append(showers[:2], HourlyMetrics{Time: at(13), StormRisk: StormLow, PrecipProb: 60, FlyabilityScore: 3})
+	showers = append(showers, metrics[2:]...)
+	if s := summarizeDay(day, showers, tc); s.OverdevelopmentAfter != "11:00" {
+		t.Errorf("OverdevelopmentAfter with showers = %q, want 11:00", s.OverdevelopmentAfter)
+	}
+}
+
+func TestSummarizeDayUsesExactScores(t *testing.T) {
+	tc := DefaultTuningConfig()
+	// Rounded, these hours average 3 stars; unrounded they average 2.2.
+	metrics := []HourlyMetrics{
+		{FlyabilityScore: 3, FlyabilityExact: 2.5},
+		{FlyabilityScore: 3, FlyabilityExact: 2.5},
+		{FlyabilityScore: 2, FlyabilityExact: 1.6},
+		{FlyabilityScore: 1, FlyabilityExact: 1.0},
+	}
+	s := summarizeDay(time.Now(), metrics, tc)
+	if math.Abs(s.BestScoreExact-2.2) > 1e-9 || s.BestScore != 2 {
+		t.Errorf("BestScore = %d (%.2f), want 2 (2.20)", s.BestScore, s.BestScoreExact)
+	}
+}
+
+func TestBuildForecastBestWindowUsesExactScore(t *testing.T) {
+	site := Site{Name: "Test", WindMin: 200, WindMax: 250}
+	day := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	hour := func(h int, precipProb float64) HourlyData {
+		return HourlyData{
+			Time: day.Add(time.Duration(h) * time.Hour), IsDay: 1,
+			WindSpeed: 12, WindGusts: 12, WindDirection: 280, // 30° off: -0.5
+			Temperature: 20, DewPoint: 10, PrecipitationProbability: precipProb,
+		}
+	}
+	// Both hours round to 3 stars, but the later one is drier and scores higher.
+	data := []HourlyData{hour(11, 40), hour(14, 0)}
+	opts := ForecastOptions{Timezone: "UTC"}
+	f, err := buildForecast(context.Background(), site, opts, fetchResult{data: data})
+	if err != nil {
+		t.Fatalf("buildForecast: %v", err)
+	}
+	hours := f.DetailedDays[0].Hours
+	if hours[0].FlyabilityScore != hours[1].FlyabilityScore || hours[0].FlyabilityExact >= hours[1].FlyabilityExact {
+		t.Fatalf("scores = %d (%.2f) and %d (%.2f), want equal stars with the second higher",
+			hours[0].FlyabilityScore, hours[0].FlyabilityExact, hours[1].FlyabilityScore, hours[1].FlyabilityExact)
+	}
+	if f.BestWindow != "Wed 14:00" {
+		t.Errorf("BestWindow = %q, want Wed 14:00", f.BestWindow)
+	}
+}
+
+func TestBuildForecastSiteDisplayOverride(t *testing.T) {
+	site := Site{Name: "Test", WindMin: 200, WindMax: 250,
+		Tuning: TuningOverrides{"display": map[string]any{"gradient": map[string]any{"low": map[string]any{"icon": "🪁"}}}}}
+	data := []HourlyData{{Time: time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC), IsDay: 1, WindSpeed: 12, WindDirection: 225}}
+	f, err := buildForecast(context.Background(), site, ForecastOptions{Timezone: "UTC"}, fetchResult{data: data})
+	if err != nil {
+		t.Fatalf("buildForecast: %v", err)
+	}
+	if f.DetailedDays[0].Hours[0].WindGradient != GradientLow {
+		t.Fatalf("gradient = %s, want %s", f.DetailedDays[0].Hours[0].WindGradient, GradientLow)
+	}
+
+	// The global config is passed, as the CLI does; the site's override wins.
+	var buf bytes.Buffer
+	FormatText(&buf, f, DefaultTuningConfig())
+	if !strings.Contains(buf.String(), "🪁") {
+		t.Errorf("text output lacks the site's gradient icon:\n%s", buf.String())
+	}
+}
+
+func TestFormatTextWarningsColumn(t *testing.T) {
+	at := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	scores := []LaunchScore{{Launch: "North"}, {Launch: "South West Bowl"}}
+	f := &SiteForecast{
+		Site:  Site{Name: "Test"},
+		Units: "mph",
+		DetailedDays: []DayForecast{{Date: at, Hours: []HourlyMetrics{
+			{Time: at, FlyabilityScore: 2, Launch: "North", LaunchScores: scores,
+				Warnings: []Warning{{Type: WarnGusts}}},
+			{Time: at.Add(time.Hour), FlyabilityScore: 4, Launch: "South West Bowl", LaunchScores: scores,
+				Warnings: []Warning{{Type: WarnWind}}},
+		}}},
+	}
+	var buf bytes.Buffer
+	FormatText(&buf, f, DefaultTuningConfig())
+	out := buf.String()
+
+	if !strings.Contains(out, HeaderScore+strings.Repeat(" ", 17)+" "+HeaderWarnings) {
+		t.Errorf("header lacks a padded score column before %q:\n%s", HeaderWarnings, out)
+	}
+	for _, want := range []string{
+		"⭐⭐ → North" + strings.Repeat(" ", 12) + " 💨",
+		"⭐⭐⭐⭐ → South West Bowl 🌬",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+}
@@ -12,7 +12,11 @@ type jsonOutput struct {
 }
 
 // FormatJSON writes the forecast as JSON, including display configuration.
+// The forecast's own Tuning, when set, takes precedence over tc.
 func FormatJSON(w io.Writer, f *SiteForecast, tc *TuningConfig) error {
+	if f.Tuning != nil {
+		tc = f.Tuning
+	}
 	out := jsonOutput{
 		SiteForecast: f,
 		Display:      tc.Display,
@@ -21,3 +25,17 @@ func FormatJSON(w io.Writer, f *SiteForecast, tc *TuningConfig) error {
 	enc.SetIndent("", "  ")
 	return enc.Encode(out)
 }
+
+// FormatComparisonJSON writes a multi-model comparison as JSON.
+func FormatComparisonJSON(w io.Writer, c *ModelComparison) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+// FormatProfileComparisonJSON writes a pilot profile comparison as JSON.
+func FormatProfileComparisonJSON(w io.Writer, c *ProfileComparison) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
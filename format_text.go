@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"unicode/utf8"
 )
 
 func starsStr(n int) string {
@@ -40,6 +41,20 @@ func rainStr(precip, prob float64) string {
 	return "-"
 }
 
+// launchStr names the hour's best launch, for sites with several.
+func launchStr(h HourlyMetrics) string {
+	if len(h.LaunchScores) < 2 {
+		return ""
+	}
+	return " → " + h.Launch
+}
+
+// scoreStr is the text forecast's score cell: storm icon, stars and, at
+// multi-launch sites, the best launch.
+func scoreStr(h HourlyMetrics) string {
+	return stormIcon(h.StormRisk) + starsStr(h.FlyabilityScore) + launchStr(h)
+}
+
 func xcIcon(xc string) string {
 	switch xc {
 	case XCEpic: return "🚀"
@@ -49,17 +64,39 @@ func xcIcon(xc string) string {
 	}
 }
 
-// FormatText writes a pretty text forecast to the writer.
+// FormatText writes a pretty text forecast to the writer. The forecast's own
+// Tuning, when set, takes precedence over tc.
 func FormatText(w io.Writer, f *SiteForecast, tc *TuningConfig) {
+	if f.Tuning != nil {
+		tc = f.Tuning
+	}
 	fmt.Fprintf(w, "\n"+ForecastTitle+"\n", f.Site.Name)
-	fmt.Fprintf(w, "   %s %s | %s %s | %s %dm\n",
-		DegreesToCompass(float64(f.Site.Aspect)),
-		LabelFacing,
-		LabelIdeal,
-		windRangeStr(f.Site.WindMin, f.Site.WindMax, f.Site.BestDir),
-		LabelElev,
-		f.Site.Elevation)
-	fmt.Fprintf(w, "   %s %s\n", LabelGenerated, f.Generated.Format("Mon 2 Jan 2006 15:04 MST"))
+	sites := []Site{f.Site}
+	if len(f.Site.Launches) > 1 {
+		sites = sites[:0]
+		for _, l := range f.Site.Launches {
+			sites = append(sites, f.Site.ForLaunch(l))
+		}
+	}
+	for i, s := range sites {
+		launch := ""
+		if len(sites) > 1 {
+			launch = f.Site.Launches[i].Name + ": "
+		}
+		fmt.Fprintf(w, "   %s%s %s | %s %s | %s %dm\n",
+			launch,
+			DegreesToCompass(float64(s.Aspect)),
+			LabelFacing,
+			LabelIdeal,
+			windRangeStr(s.WindMin, s.WindMax, s.BestDir),
+			LabelElev,
+			s.Elevation)
+	}
+	generated := f.Generated.Format("Mon 2 Jan 2006 15:04 MST")
+	if f.FromCache {
+		generated += " " + LabelCached
+	}
+	fmt.Fprintf(w, "   %s %s | %s %s\n", LabelGenerated, generated, LabelModel, f.Model.Description)
 
 	for i, day := range f.DetailedDays {
 		label := LabelToday
@@ -67,11 +104,17 @@ func FormatText(w io.Writer, f *SiteForecast, tc *TuningConfig) {
 		if i >= 2 { label = day.Date.Format("Mon 2 Jan") }
 		
 		fmt.Fprintf(w, "\n━━━ %s (%s) ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n", label, day.Date.Format("Mon 2 Jan"))
-		fmt.Fprintf(w, "        %-8s %-5s %-6s %-9s %-10s %-5s %-6s %s\n",
-			HeaderWind, HeaderDir, HeaderGust, HeaderGradient, HeaderThermal, HeaderCloud, HeaderRain, HeaderScore)
+		// Pad the score cell to the day's widest, launch names included, so
+		// the warnings line up.
+		scoreWidth := utf8.RuneCountInString(HeaderScore)
+		for _, h := range day.Hours {
+			scoreWidth = max(scoreWidth, utf8.RuneCountInString(scoreStr(h)))
+		}
+		fmt.Fprintf(w, "        %-8s %-5s %-6s %-9s %-10s %-6s %-5s %-6s %-*s %s\n",
+			HeaderWind, HeaderDir, HeaderGust, HeaderGradient, HeaderThermal, HeaderClimb, HeaderCloud, HeaderRain, scoreWidth, HeaderScore, HeaderWarnings)
 
 		for _, h := range day.Hours {
-			fmt.Fprintf(w, "%s  %-8s %-5s %-6s %s %-5s %s %-7s %-5s %-6s %s\n",
+			fmt.Fprintf(w, "%s  %-8s %-5s %-6s %s %-5s %s %-7s %-6s %-5s %-6s %-*s %s\n",
 				h.Time.Format("15:04"),
 				fmt.Sprintf("%.0f%s", h.WindSpeed, f.Units),
 				h.WindDirStr,
@@ -80,33 +123,48 @@ func FormatText(w io.Writer, f *SiteForecast, tc *TuningConfig) {
 				fmt.Sprintf("%s(+%.0f)", h.WindGradient, h.WindGradientDiff),
 				thermalIcon(h.ThermalRating),
 				h.ThermalRating,
+				climbStr(h.ThermalStrengthMS),
 				cloudIcon(h.CloudCover),
 				rainStr(h.Precipitation, h.PrecipProb),
-				starsStr(h.FlyabilityScore))
+				scoreWidth, scoreStr(h),
+				warningIcons(h.Warnings))
 		}
+		writeWarnings(w, day.Hours)
 
 		s := day.Summary
 		if len(day.Hours) > 0 {
 			h0 := day.Hours[len(day.Hours)/2] // mid-day representative
 			fmt.Fprintf(w, "\n"+CloudbaseLabel+"\n",
-				CloudbaseStr(h0.CloudbaseFt, tc), h0.CAPE, h0.FreezingLevel)
+				CloudbaseStr(h0.CloudbaseFt, tc), h0.CloudbaseAMSLFt, h0.ThermalTopFt, h0.CAPE, h0.FreezingLevel)
+			if h0.InversionStrengthC > 0 {
+				capped := ""
+				if h0.ThermalsCapped {
+					capped = LabelCapsThermals
+				}
+				fmt.Fprintf(w, InversionLabel+"\n", h0.InversionBaseAMSLFt, h0.InversionStrengthC, capped)
+			}
+			fmt.Fprintf(w, WindDirLabel+"\n", s.WindDirStr, s.WindDirVariability, variabilityStr(s.WindDirVariability))
+			fmt.Fprintf(w, ThermalStrengthLabel+"\n", s.MaxThermalStrength)
 			fmt.Fprintf(w, OrographicLabel+"\n", h0.OrographicLift)
 			fmt.Fprintf(w, XCLabel+"\n", s.XCPotential, xcIcon(s.XCPotential))
+			if s.OverdevelopmentAfter != "" {
+				fmt.Fprintf(w, OverdevelopmentLabel+"\n", s.OverdevelopmentAfter)
+			}
 		}
 	}
 
 	if len(f.ExtendedDays) > 0 {
 		fmt.Fprintf(w, "\n━━━ "+ExtendedOutlookTitle+" ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-		fmt.Fprintf(w, "%-12s %-10s %-6s %-10s %-6s %s\n",
+		fmt.Fprintf(w, "%-12s %-10s %-10s %-10s %-6s %s\n",
 			HeaderDay, HeaderExtWind, HeaderExtDir, HeaderExtThermal, HeaderExtRain, HeaderExtScore)
 		for _, d := range f.ExtendedDays {
-			fmt.Fprintf(w, "%-12s %-10s %-6s %-10s %-6s %s\n",
+			fmt.Fprintf(w, "%-12s %-10s %-10s %-10s %-6s %s\n",
 				d.Date.Format("Mon 2 Jan"),
 				fmt.Sprintf("%.0f%s", d.AvgWindSpeed, f.Units),
-				d.WindDirStr,
+				fmt.Sprintf("%s ±%.0f°", d.WindDirStr, d.WindDirVariability),
 				d.ThermalRating,
 				fmt.Sprintf("%.0f%%", d.MaxPrecipProb),
-				starsStr(d.BestScore))
+				stormIcon(d.StormRisk)+starsStr(d.BestScore))
 		}
 	}
 
@@ -116,6 +174,213 @@ func FormatText(w io.Writer, f *SiteForecast, tc *TuningConfig) {
 	fmt.Fprintln(w)
 }
 
+// warningIcon returns the table icon for a warning type.
+func warningIcon(typ string) string {
+	switch typ {
+	case WarnGusts:
+		return "💨"
+	case WarnWind:
+		return "🌬"
+	case WarnWindAloft:
+		return "🎈"
+	case WarnGradient:
+		return "📶"
+	case WarnDirection:
+		return "🧭"
+	case WarnRotor:
+		return "🌀"
+	case WarnRain:
+		return "🌧"
+	case WarnCloudbase:
+		return "🌫"
+	case WarnVisibility:
+		return "👁"
+	case WarnStorm:
+		return "⛈"
+	default:
+		return "⚠️"
+	}
+}
+
+func warningIcons(ws []Warning) string {
+	var b strings.Builder
+	for _, wn := range ws {
+		b.WriteString(warningIcon(wn.Type))
+	}
+	return b.String()
+}
+
+func severityIcon(severity string) string {
+	if severity == SeverityDanger {
+		return "🔴"
+	}
+	return "⚠️"
+}
+
+// writeWarnings lists each hour's warnings under the hourly table.
+func writeWarnings(w io.Writer, hours []HourlyMetrics) {
+	header := false
+	for _, h := range hours {
+		if len(h.Warnings) == 0 {
+			continue
+		}
+		if !header {
+			fmt.Fprintf(w, "\n%s\n", WarningsTitle)
+			header = true
+		}
+		msgs := make([]string, len(h.Warnings))
+		for i, wn := range h.Warnings {
+			msgs[i] = severityIcon(wn.Severity) + " " + wn.Message
+		}
+		fmt.Fprintf(w, "%s  %s\n", h.Time.Format("15:04"), strings.Join(msgs, " · "))
+	}
+}
+
+// stormIcon flags hours and days at risk of overdevelopment.
+func stormIcon(risk string) string {
+	switch risk {
+	case StormHigh:
+		return "⛈"
+	case StormModerate:
+		return "🌩"
+	default:
+		return ""
+	}
+}
+
+// FormatScoreBreakdown writes how each detailed hour's flyability score was
+// reached: the base score, every bonus and penalty, and the raw total.
+func FormatScoreBreakdown(w io.Writer, f *SiteForecast) {
+	fmt.Fprintf(w, "\n"+ScoreBreakdownTitle+"\n", f.Site.Name)
+	for _, day := range f.DetailedDays {
+		fmt.Fprintf(w, "\n━━━ %s ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n", day.Date.Format("Mon 2 Jan"))
+		for _, h := range day.Hours {
+			b := h.ScoreBreakdown
+			parts := []string{fmt.Sprintf("%s %.2f", LabelBase, b.Base)}
+			for _, c := range b.Contributions {
+				parts = append(parts, fmt.Sprintf("%+.2f %s", c.Value, c.Reason))
+			}
+			line := fmt.Sprintf("%s  %s = %.2f → %.2f → %d", h.Time.Format("15:04"), strings.Join(parts, ", "), b.Raw, b.Exact, b.Score)
+			if b.CappedBy != "" {
+				line += fmt.Sprintf(" ("+LabelCappedBy+")", b.CappedBy)
+			}
+			if len(h.LaunchScores) > 1 {
+				launches := make([]string, len(h.LaunchScores))
+				for i, ls := range h.LaunchScores {
+					launches[i] = fmt.Sprintf("%s %.2f", ls.Launch, ls.FlyabilityExact)
+				}
+				line += fmt.Sprintf(" ["+LabelLaunches+"]", h.Launch, strings.Join(launches, ", "))
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+func climbStr(ms float64) string {
+	if ms <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", ms)
+}
+
+func variabilityStr(stdDev float64) string {
+	if stdDev > WindDirVariableThreshold {
+		return " " + LabelVariable
+	}
+	return ""
+}
+
 func windRangeStr(min, max, best int) string {
 	return fmt.Sprintf("%s-%s (%s)", DegreesToCompass(float64(min)), DegreesToCompass(float64(max)), DegreesToCompass(float64(best)))
 }
+
+func confidenceIcon(c string) string {
+	switch c {
+	case ConfidenceHigh:
+		return "🟢"
+	case ConfidenceMedium:
+		return "🟡"
+	default:
+		return "🔴"
+	}
+}
+
+// FormatComparisonText writes a multi-model comparison as a text table.
+func FormatComparisonText(w io.Writer, c *ModelComparison) {
+	fmt.Fprintf(w, "\n"+CompareTitle+"\n", c.Site.Name)
+	names := make([]string, len(c.Models))
+	for i, m := range c.Models {
+		names[i] = m.Description
+	}
+	fmt.Fprintf(w, "   %s %s\n", LabelModels, strings.Join(names, ", "))
+	fmt.Fprintf(w, "   %s %s\n", LabelGenerated, c.Generated.Format("Mon 2 Jan 2006 15:04 MST"))
+
+	lastDay := ""
+	for _, h := range c.Hours {
+		day := h.Time.Format("Mon 2 Jan")
+		if day != lastDay {
+			lastDay = day
+			fmt.Fprintf(w, "\n━━━ %s ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n", day)
+			fmt.Fprintf(w, "        %-12s %-10s %-10s %-24s %s\n",
+				HeaderWind+" "+HeaderSpread, HeaderDir+" "+HeaderSpread, HeaderScore, HeaderThermal, HeaderConfidence)
+		}
+		var scores, thermals []string
+		for _, m := range h.Members {
+			scores = append(scores, fmt.Sprintf("%d", m.FlyabilityScore))
+			thermals = append(thermals, m.ThermalRating)
+		}
+		fmt.Fprintf(w, "%s  %-12s %-10s %-10s %-24s %s %s\n",
+			h.Time.Format("15:04"),
+			fmt.Sprintf("%.0f%s ±%.0f", h.WindSpeedMean, c.Units, h.WindSpeedSpread/2),
+			fmt.Sprintf("%s ±%.0f°", h.WindDirStr, h.WindDirSpread/2),
+			strings.Join(scores, "/"),
+			strings.Join(thermals, "/"),
+			confidenceIcon(h.Confidence),
+			h.Confidence)
+	}
+	fmt.Fprintln(w)
+}
+
+// FormatProfileComparisonText writes a pilot profile comparison as a text
+// table with one score column per profile.
+func FormatProfileComparisonText(w io.Writer, c *ProfileComparison) {
+	fmt.Fprintf(w, "\n"+ProfileCompareTitle+"\n", c.Site.Name)
+	names := make([]string, len(c.Profiles))
+	for i, p := range c.Profiles {
+		names[i] = p.Name
+	}
+	fmt.Fprintf(w, "   %s %s\n", LabelProfiles, strings.Join(names, ", "))
+	fmt.Fprintf(w, "   %s %s\n", LabelGenerated, c.Generated.Format("Mon 2 Jan 2006 15:04 MST"))
+
+	lastDay := ""
+	for _, h := range c.Hours {
+		day := h.Time.Format("Mon 2 Jan")
+		if day != lastDay {
+			lastDay = day
+			fmt.Fprintf(w, "\n━━━ %s ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n", day)
+			fmt.Fprintf(w, "        %-8s %-5s %-6s", HeaderWind, HeaderDir, HeaderGust)
+			for _, name := range names {
+				fmt.Fprintf(w, " %-12s", name)
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s  %-8s %-5s %-6s",
+			h.Time.Format("15:04"),
+			fmt.Sprintf("%.0f%s", h.WindSpeed, c.Units),
+			h.WindDirStr,
+			fmt.Sprintf("%.0f", h.WindGusts))
+		for _, s := range h.Scores {
+			fmt.Fprintf(w, " %-12s", fmt.Sprintf("%d (%.1f)", s.FlyabilityScore, s.FlyabilityExact))
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w)
+	for _, name := range names {
+		if bw := c.BestWindows[name]; bw != "" {
+			fmt.Fprintf(w, "%-12s "+BestWindowLabel+"\n", name, bw)
+		}
+	}
+	fmt.Fprintln(w)
+}
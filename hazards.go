@@ -0,0 +1,297 @@
+package pgforecast
+
+import (
+	"fmt"
+	"time"
+)
+
+// WMO weather codes reported by Open-Meteo for thunderstorms.
+const (
+	// WMOThunderstorm is a slight or moderate thunderstorm.
+	WMOThunderstorm = 95
+
+	// WMOThunderstormHail is a thunderstorm with slight hail.
+	WMOThunderstormHail = 96
+
+	// WMOThunderstormHeavyHail is a thunderstorm with heavy hail.
+	WMOThunderstormHeavyHail = 99
+)
+
+const (
+	// MidLevelPressureBase is the bottom (hPa) of the mid-level layer whose
+	// moisture feeds cumulonimbus growth.
+	MidLevelPressureBase = 700
+
+	// MidLevelPressureTop is the top (hPa) of the mid-level moisture layer.
+	MidLevelPressureTop = 500
+)
+
+// IsThunderstormCode reports whether a WMO weather code is a thunderstorm.
+func IsThunderstormCode(code int) bool {
+	switch code {
+	case WMOThunderstorm, WMOThunderstormHail, WMOThunderstormHeavyHail:
+		return true
+	}
+	return false
+}
+
+// midLevelHumidity returns the mean relative humidity (%) of the pressure
+// levels between MidLevelPressureBase and MidLevelPressureTop, and false when
+// the profile has no humidity there.
+func midLevelHumidity(levels []PressureLevel) (float64, bool) {
+	var total float64
+	n := 0
+	for _, l := range levels {
+		if l.Pressure > MidLevelPressureBase || l.Pressure < MidLevelPressureTop || l.RelativeHumidity <= 0 {
+			continue
+		}
+		total += l.RelativeHumidity
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return total / float64(n), true
+}
+
+// CalcStormRisk rates the risk of overdevelopment and thunderstorms for one
+// hour. A thunderstorm weather code is always High. Otherwise CAPE and the
+// lifted index must show instability before mid-level moisture and showers
+// add to the risk, and strong convective inhibition takes a point off.
+func CalcStormRisk(h *HourlyData, tc *TuningConfig) string {
+	if IsThunderstormCode(h.WeatherCode) {
+		return StormHigh
+	}
+	s := tc.Storm
+
+	points := 0
+	switch {
+	case h.CAPE >= s.CAPEHigh:
+		points += 2
+	case h.CAPE >= s.CAPEModerate:
+		points++
+	}
+	switch {
+	case h.LiftedIndex <= s.LIHigh:
+		points += 2
+	case h.LiftedIndex <= s.LIModerate:
+		points++
+	}
+	if points == 0 {
+		return StormNone
+	}
+
+	if rh, ok := midLevelHumidity(h.PressureLevels); ok && rh >= s.MidLevelRH {
+		points++
+	}
+	// Showers in unstable air are the first sign of overdevelopment.
+	if isShowery(h.Precipitation, h.PrecipitationProbability, tc) {
+		points++
+	}
+	if h.CIN >= tc.Convection.CINStrong {
+		points--
+	}
+
+	switch {
+	case points >= 4:
+		return StormHigh
+	case points == 3:
+		return StormModerate
+	case points > 0:
+		return StormLow
+	default:
+		return StormNone
+	}
+}
+
+// isShowery reports whether an hour has rain or a shower probability of at
+// least storm.precip_prob.
+func isShowery(precip, precipProb float64, tc *TuningConfig) bool {
+	return precip > 0 || precipProb >= tc.Storm.PrecipProb
+}
+
+// stormRank returns the numeric rank of a storm risk level for comparison.
+func stormRank(risk string) int {
+	switch risk {
+	case StormLow:
+		return 1
+	case StormModerate:
+		return 2
+	case StormHigh:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// CapScoreForStormRisk limits a flyability score for an hour at risk of
+// overdevelopment. High risk hours default to ScoreMin; the hour's StormRisk
+// marks them as not flyable.
+func CapScoreForStormRisk(score int, risk string, tc *TuningConfig) int {
+	switch risk {
+	case StormHigh:
+		return min(score, tc.Storm.HighMaxScore)
+	case StormModerate:
+		return min(score, tc.Storm.ModerateMaxScore)
+	}
+	return score
+}
+
+// OverdevelopmentOnset returns when a day's overdevelopment should be
+// expected: the first hour whose storm risk is Moderate or higher, or, when
+// earlier, storm.shower_lookahead_hours before the first shower in unstable
+// air, as the cumulus that brings it is already building. The metrics must be
+// in time order. It returns false when the day has neither.
+func OverdevelopmentOnset(metrics []HourlyMetrics, tc *TuningConfig) (time.Time, bool) {
+	var onset time.Time
+	found := false
+	lookahead := time.Duration(tc.Storm.ShowerLookaheadHours) * time.Hour
+	for _, m := range metrics {
+		t := time.Time{}
+		switch {
+		case stormRank(m.StormRisk) >= stormRank(StormLow) && isShowery(m.Precipitation, m.PrecipProb, tc):
+			t = m.Time.Add(-lookahead)
+			if t.Before(metrics[0].Time) {
+				t = metrics[0].Time
+			}
+		case stormRank(m.StormRisk) >= stormRank(StormModerate):
+			t = m.Time
+		default:
+			continue
+		}
+		if !found || t.Before(onset) {
+			onset, found = t, true
+		}
+	}
+	return onset, found
+}
+
+// WindAloftPressureHPa is the highest level (lowest pressure) checked for
+// strong wind aloft — roughly 3000m, the top of most flights.
+const WindAloftPressureHPa = 700
+
+// Warning types, used as the Type of a Warning.
+const (
+	// WarnGusts flags a gusty hour, by gust factor or absolute gust speed.
+	WarnGusts = "gusts"
+	// WarnWind flags a surface wind above the acceptable range.
+	WarnWind = "wind"
+	// WarnWindAloft flags strong wind between launch and ~3000m.
+	WarnWindAloft = "wind_aloft"
+	// WarnGradient flags a Medium or High wind gradient.
+	WarnGradient = "gradient"
+	// WarnDirection flags wind outside the site's direction range.
+	WarnDirection = "direction"
+	// WarnRotor flags wind over the back of the hill, putting launch in its lee.
+	WarnRotor = "rotor"
+	// WarnRain flags rain or a high chance of it.
+	WarnRain = "rain"
+	// WarnCloudbase flags fog or a low cloudbase.
+	WarnCloudbase = "cloudbase"
+	// WarnVisibility flags poor visibility.
+	WarnVisibility = "visibility"
+	// WarnStorm flags a Moderate or High storm risk.
+	WarnStorm = "storm"
+)
+
+// CalcWarnings lists the safety hazards for one hour, from the raw weather
+// and the metrics already computed for it. It never returns nil, so the
+// list always encodes as a JSON array. Wind speeds are labelled with units,
+// the forecast's wind speed unit; empty means mph, Open-Meteo's default.
+func CalcWarnings(h *HourlyData, m *HourlyMetrics, site Site, tc *TuningConfig, units string) []Warning {
+	if units == "" {
+		units = "mph"
+	}
+	ws := []Warning{}
+	add := func(typ, severity, format string, args ...any) {
+		ws = append(ws, Warning{Type: typ, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case h.WindSpeed > tc.Wind.DangerousMax:
+		add(WarnWind, SeverityDanger, WarnWindMsg, h.WindSpeed, units)
+	case h.WindSpeed > tc.Wind.AcceptableMax:
+		add(WarnWind, SeverityCaution, WarnWindMsg, h.WindSpeed, units)
+	}
+
+	if h.WindSpeed > 0 {
+		factor := h.WindGusts / h.WindSpeed
+		switch {
+		case factor > tc.Wind.DangerousGustFactor || h.WindGusts > tc.Wind.DangerousMax:
+			add(WarnGusts, SeverityDanger, WarnGustsMsg, h.WindGusts, units, factor)
+		case factor > tc.Wind.MaxGustFactor:
+			add(WarnGusts, SeverityCaution, WarnGustsMsg, h.WindGusts, units, factor)
+		}
+	}
+
+	// Strongest wind between launch and WindAloftPressureHPa.
+	var aloft *PressureLevel
+	for i, l := range h.PressureLevels {
+		if l.Pressure < WindAloftPressureHPa || l.GeopotentialHeight < float64(site.Elevation) {
+			continue
+		}
+		if aloft == nil || l.WindSpeed > aloft.WindSpeed {
+			aloft = &h.PressureLevels[i]
+		}
+	}
+	if aloft != nil {
+		switch {
+		case aloft.WindSpeed > tc.Wind.DangerousMax:
+			add(WarnWindAloft, SeverityDanger, WarnWindAloftMsg, aloft.WindSpeed, units, aloft.Pressure, aloft.GeopotentialHeight)
+		case aloft.WindSpeed > tc.Wind.AcceptableMax:
+			add(WarnWindAloft, SeverityCaution, WarnWindAloftMsg, aloft.WindSpeed, units, aloft.Pressure, aloft.GeopotentialHeight)
+		}
+	}
+
+	switch m.WindGradient {
+	case GradientHigh:
+		add(WarnGradient, SeverityDanger, WarnGradientMsg, m.WindGradient, m.WindGradientDiff)
+	case GradientMedium:
+		add(WarnGradient, SeverityCaution, WarnGradientMsg, m.WindGradient, m.WindGradientDiff)
+	}
+
+	if off := distanceFromWindRange(h.WindDirection, site.WindMin, site.WindMax); off > WindDirMarginalAngle {
+		severity := SeverityCaution
+		if off > WindDirModerateOffAngle {
+			severity = SeverityDanger
+		}
+		add(WarnDirection, severity, WarnDirectionMsg, m.WindDirStr, off)
+	}
+
+	// Wind blowing over the back of the hill leaves launch in its lee.
+	if h.WindSpeed >= tc.Orographic.MinWindSpeed && angleDiff(h.WindDirection, float64(site.Aspect)) > tc.Warnings.RotorAngle {
+		add(WarnRotor, SeverityDanger, WarnRotorMsg)
+	}
+
+	switch {
+	case h.Precipitation > 0:
+		add(WarnRain, SeverityDanger, WarnRainMsg, h.Precipitation)
+	case h.PrecipitationProbability > PrecipProbHighThreshold:
+		add(WarnRain, SeverityCaution, WarnRainProbMsg, h.PrecipitationProbability)
+	}
+
+	switch {
+	case m.CloudbaseFt <= tc.Cloudbase.MinRealisticFt:
+		add(WarnCloudbase, SeverityDanger, WarnFogMsg)
+	case m.CloudbaseFt < tc.Warnings.LowCloudbaseFt:
+		add(WarnCloudbase, SeverityCaution, WarnLowCloudbaseMsg, m.CloudbaseFt)
+	}
+
+	// Visibility of 0 means the model did not provide it.
+	switch {
+	case h.Visibility <= 0:
+	case h.Visibility < tc.Warnings.PoorVisibilityM:
+		add(WarnVisibility, SeverityDanger, WarnVisibilityMsg, h.Visibility/MetersPerKm)
+	case h.Visibility < tc.Warnings.LowVisibilityM:
+		add(WarnVisibility, SeverityCaution, WarnVisibilityMsg, h.Visibility/MetersPerKm)
+	}
+
+	switch m.StormRisk {
+	case StormHigh:
+		add(WarnStorm, SeverityDanger, WarnStormMsg, m.StormRisk)
+	case StormModerate:
+		add(WarnStorm, SeverityCaution, WarnStormMsg, m.StormRisk)
+	}
+
+	return ws
+}
@@ -68,6 +68,13 @@ const (
 
 	// WindDirMarginalAngle is the angular distance (°) within which no off-direction penalty applies.
 	WindDirMarginalAngle = 20.0
+
+	// SecondsPerHour converts sunshine duration to a fraction of the hour.
+	SecondsPerHour = 3600.0
+
+	// WindDirVariableThreshold is the circular standard deviation (°) of a day's
+	// wind direction above which the day is flagged as variable.
+	WindDirVariableThreshold = 45.0
 )
 
 // DegreesToCompass converts degrees to compass direction string.
@@ -89,7 +96,8 @@ func CalcWindGradient(surface float64, levels []PressureLevel, tc *TuningConfig)
 	maxUpper := surface
 	for _, l := range levels {
 		// Only check levels a paraglider might actually reach: 1000-850 hPa
-		// 1000 ~60-160m, 950 ~500m, 925 ~750m, 900 ~1000m, 850 ~1500m
+		// 1000 ~60-160m, 975 ~300m, 950 ~500m, 925 ~750m, 900 ~1000m,
+		// 875 ~1250m, 850 ~1500m
 		if l.Pressure >= PressureLevelMinHPa && l.Pressure <= PressureLevelMaxHPa && l.WindSpeed > maxUpper {
 			maxUpper = l.WindSpeed
 		}
@@ -111,6 +119,50 @@ func CalcWindGradient(surface float64, levels []PressureLevel, tc *TuningConfig)
 
 // CalcThermalRating estimates thermal potential from CAPE and lapse rate.
 func CalcThermalRating(cape float64, levels []PressureLevel, tc *TuningConfig) string {
+	return thermalRatingForScore(thermalScore(cape, levels, tc))
+}
+
+// CalcHourThermalRating rates thermals for one hour. It starts from the CAPE
+// and lapse-rate score of CalcThermalRating and adjusts it by convective
+// inhibition, lifted index, boundary-layer depth and sunshine when the model
+// provides them, so that high CAPE under a strong cap is not rated strong.
+func CalcHourThermalRating(h *HourlyData, tc *TuningConfig) string {
+	c := tc.Convection
+	score := thermalScore(h.CAPE, h.PressureLevels, tc)
+
+	switch {
+	case h.CIN >= c.CINStrong:
+		score -= 2
+	case h.CIN >= c.CINModerate:
+		score--
+	}
+
+	switch {
+	case h.LiftedIndex <= c.LIUnstable:
+		score++
+	case h.LiftedIndex >= c.LIStable:
+		score--
+	}
+
+	if h.BoundaryLayerHeight > 0 {
+		switch {
+		case h.BoundaryLayerHeight < c.ShallowBoundaryLayerM:
+			score--
+		case h.BoundaryLayerHeight >= c.DeepBoundaryLayerM:
+			score++
+		}
+	}
+
+	if h.SunshineDuration != nil && *h.SunshineDuration/SecondsPerHour < c.MinSunshineFraction {
+		score--
+	}
+
+	return thermalRatingForScore(score)
+}
+
+// thermalScore scores thermal potential from CAPE brackets plus lapse-rate
+// bonuses.
+func thermalScore(cape float64, levels []PressureLevel, tc *TuningConfig) float64 {
 	lapseRate := calcLapseRate(levels)
 
 	score := 0.0
@@ -130,7 +182,10 @@ func CalcThermalRating(cape float64, levels []PressureLevel, tc *TuningConfig) s
 	if lapseRate > LapseRateStrongThreshold {
 		score += 1
 	}
+	return score
+}
 
+func thermalRatingForScore(score float64) string {
 	switch {
 	case score >= 5:
 		return ThermalExtreme
@@ -145,6 +200,22 @@ func CalcThermalRating(cape float64, levels []PressureLevel, tc *TuningConfig) s
 	}
 }
 
+// thermalRank orders thermal ratings from ThermalNone (0) to ThermalExtreme (4).
+func thermalRank(rating string) int {
+	switch rating {
+	case ThermalWeak:
+		return 1
+	case ThermalModerate:
+		return 2
+	case ThermalStrong:
+		return 3
+	case ThermalExtreme:
+		return 4
+	default:
+		return 0
+	}
+}
+
 func calcLapseRate(levels []PressureLevel) float64 {
 	var t925, h925, t700, h700 float64
 	var found925, found700 bool
@@ -180,7 +251,9 @@ func CalcCAPERating(cape float64, tc *TuningConfig) string {
 	}
 }
 
-// CalcCloudbaseFt estimates cloudbase in feet from temp and dewpoint.
+// CalcCloudbaseFt estimates cloudbase in feet from temp and dewpoint using the
+// 2.5°C/1000ft spread rule. CalcCloudbase uses it as a fallback when no
+// pressure-level profile is available.
 func CalcCloudbaseFt(temp, dewpoint float64, tc *TuningConfig) int {
 	spread := temp - dewpoint
 	if spread < 0 {
@@ -193,6 +266,159 @@ func CalcCloudbaseFt(temp, dewpoint float64, tc *TuningConfig) int {
 	return ft
 }
 
+// StableLayer is a layer of the sounding that suppresses thermals: a stable
+// layer with a lapse rate below the tuning threshold, or an inversion where
+// temperature rises with height.
+type StableLayer struct {
+	BaseAMSLFt int     `json:"base_amsl_ft"`
+	TopAMSLFt  int     `json:"top_amsl_ft"`
+	LapseRate  float64 `json:"lapse_rate"` // °C/km; negative in an inversion
+	StrengthC  float64 `json:"strength_c"` // temperature rise from base to top
+	Inversion  bool    `json:"inversion"`
+}
+
+// CloudbaseEstimate holds cloudbase, thermal top and inversion analysis for
+// one hour.
+type CloudbaseEstimate struct {
+	CloudbaseFt      int    // above launch
+	CloudbaseAMSLFt  int    // above mean sea level
+	ThermalTopFt     int    // above launch; the usable climb height, capped at cloudbase and by inversions
+	ThermalTopAMSLFt int    // above mean sea level
+	Method           string // CloudbaseMethodProfile or CloudbaseMethodSurface
+
+	// StableLayers lists the stable layers and inversions above launch,
+	// lowest first.
+	StableLayers []StableLayer
+
+	// Inversion is the inversion capping the thermals, or failing that the
+	// lowest inversion above launch. InversionStrengthC is 0 when there is none.
+	InversionBaseFt     int // above launch
+	InversionBaseAMSLFt int
+	InversionStrengthC  float64
+	ThermalsCapped      bool // ThermalTopFt is limited by the inversion
+}
+
+// CalcCloudbase estimates cloudbase by lifting a surface parcel through the
+// pressure-level profile to its lifted condensation level, and the thermal top
+// as the height where a dry thermal (surface temperature plus the tuning
+// trigger excess) loses buoyancy, capped at cloudbase. The profile is also
+// scanned for stable layers; when the thermal dies inside an inversion, the
+// inversion base becomes the thermal top. Heights are reported above launch
+// (site.Elevation) and AMSL; the thermal top never exceeds the model's
+// boundary-layer height when it is provided. When the profile is unavailable
+// it falls back to the surface spread rule of CalcCloudbaseFt.
+func CalcCloudbase(h *HourlyData, site Site, tc *TuningConfig) CloudbaseEstimate {
+	elev := float64(site.Elevation)
+	elevFt := int(math.Round(elev * MetersToFeet))
+
+	// The model boundary layer, when provided, bounds the convective layer.
+	blFt := math.MaxInt
+	if h.BoundaryLayerHeight > 0 {
+		blFt = int(math.Round(h.BoundaryLayerHeight * MetersToFeet))
+	}
+
+	profile, ok := buildProfile(h, elev)
+	if !ok {
+		ft := CalcCloudbaseFt(h.Temperature, h.DewPoint, tc)
+		top := min(ft, blFt)
+		return CloudbaseEstimate{
+			CloudbaseFt:      ft,
+			CloudbaseAMSLFt:  ft + elevFt,
+			ThermalTopFt:     top,
+			ThermalTopAMSLFt: top + elevFt,
+			Method:           CloudbaseMethodSurface,
+		}
+	}
+
+	lcl := profile.liftedCondensationLevel()
+	dryTop := profile.dryThermalTop(tc.Cloudbase.ThermalTriggerC)
+	top := math.Min(dryTop, lcl)
+
+	est := CloudbaseEstimate{Method: CloudbaseMethodProfile}
+	var inversion *stableLayer
+	layers := profile.stableLayers(tc.Thermal.StableLapseRate)
+	for i := range layers {
+		l := &layers[i]
+		isInversion := l.StrengthC >= tc.Thermal.InversionMinC
+		est.StableLayers = append(est.StableLayers, StableLayer{
+			BaseAMSLFt: int(math.Round(l.BaseM * MetersToFeet)),
+			TopAMSLFt:  int(math.Round(l.TopM * MetersToFeet)),
+			LapseRate:  l.LapseRate,
+			StrengthC:  l.StrengthC,
+			Inversion:  isInversion,
+		})
+		if !isInversion {
+			continue
+		}
+		// The thermal stalls inside this inversion below cloudbase: its
+		// base is the usable ceiling.
+		if !est.ThermalsCapped && l.BaseM <= dryTop && dryTop <= l.TopM+ParcelStepM && l.BaseM < lcl {
+			inversion = l
+			est.ThermalsCapped = true
+			top = math.Min(top, l.BaseM)
+		}
+		if inversion == nil {
+			inversion = l
+		}
+	}
+
+	cloudbaseFt := int(math.Round((lcl - elev) * MetersToFeet))
+	if cloudbaseFt < tc.Cloudbase.MinRealisticFt {
+		cloudbaseFt = tc.Cloudbase.MinRealisticFt
+	}
+	topFt := min(int(math.Round((top-elev)*MetersToFeet)), blFt)
+	if topFt < 0 {
+		topFt = 0
+	}
+	est.CloudbaseFt = cloudbaseFt
+	est.CloudbaseAMSLFt = cloudbaseFt + elevFt
+	est.ThermalTopFt = topFt
+	est.ThermalTopAMSLFt = topFt + elevFt
+	if inversion != nil {
+		est.InversionBaseFt = int(math.Round((inversion.BaseM - elev) * MetersToFeet))
+		est.InversionBaseAMSLFt = est.InversionBaseFt + elevFt
+		est.InversionStrengthC = inversion.StrengthC
+	}
+	return est
+}
+
+// CapThermalRating limits a thermal rating by the usable ceiling above launch:
+// thermals that cannot climb above tc.Thermal.CeilingNoneFt are rated None,
+// and those capped below tc.Thermal.CeilingWeakFt are at most Weak, however
+// much CAPE is available.
+func CapThermalRating(rating string, ceilingFt int, tc *TuningConfig) string {
+	switch {
+	case ceilingFt < tc.Thermal.CeilingNoneFt:
+		return ThermalNone
+	case ceilingFt < tc.Thermal.CeilingWeakFt && thermalRank(rating) > thermalRank(ThermalWeak):
+		return ThermalWeak
+	default:
+		return rating
+	}
+}
+
+// CalcThermalStrength estimates the average thermal climb rate (m/s) from
+// the convective velocity scale W* = (g/T · H · zi)^⅓, where H is the
+// kinematic surface heat flux — the tuning fraction of shortwave radiation
+// heating the air — and zi is the convective layer depth (the thermal top
+// above launch). The glider's sink rate is subtracted; the result is never
+// negative.
+func CalcThermalStrength(h *HourlyData, thermalTopFt int, tc *TuningConfig) float64 {
+	ts := tc.ThermalStrength
+	depth := float64(thermalTopFt) / MetersToFeet
+	if h.ShortwaveRadiation <= 0 || depth <= 0 {
+		return 0
+	}
+	tk := h.Temperature + KelvinOffset
+	rho := StandardAirDensity
+	if h.PressureMSL > 0 {
+		rho = h.PressureMSL * 100 / (GasConstantDryAir * tk)
+	}
+	heatFlux := ts.SensibleHeatFraction * h.ShortwaveRadiation / (rho * SpecificHeatDryAir)
+	wStar := math.Cbrt(Gravity / tk * heatFlux * depth)
+	return math.Max(0, wStar-ts.SinkRateMS)
+}
+
 // CloudbaseStr returns a display string for cloudbase, showing "Fog" for very low values.
 func CloudbaseStr(ft int, tc *TuningConfig) string {
 	if ft <= tc.Cloudbase.MinRealisticFt {
@@ -221,6 +447,57 @@ func CalcOrographicLift(windDir, windSpeed float64, siteAspect int, tc *TuningCo
 	}
 }
 
+// circularMean returns the weighted mean of a set of directions in degrees
+// (0-360). A nil weights slice weights every direction equally.
+func circularMean(dirs, weights []float64) float64 {
+	mean, _ := CircularStats(dirs, weights)
+	return mean
+}
+
+// CircularStats returns the weighted circular mean and circular standard
+// deviation of a set of directions in degrees. Directions are averaged as
+// vectors, so 350° and 10° average to 0° rather than 180°. A nil weights
+// slice (or weights summing to zero) weights every direction equally. The
+// standard deviation is near 0 for a steady direction and grows as the
+// directions spread; fully opposed directions return 180.
+func CircularStats(dirs, weights []float64) (mean, stdDev float64) {
+	if len(dirs) == 0 {
+		return 0, 0
+	}
+	total := 0.0
+	for i := range weights {
+		total += weights[i]
+	}
+	if total <= 0 {
+		weights = nil
+		total = float64(len(dirs))
+	}
+
+	var sinSum, cosSum float64
+	for i, d := range dirs {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		rad := d * math.Pi / DegreesHalfCircle
+		sinSum += w * math.Sin(rad)
+		cosSum += w * math.Cos(rad)
+	}
+	mean = math.Atan2(sinSum, cosSum) * DegreesHalfCircle / math.Pi
+	if mean < 0 {
+		mean += DegreesFullCircle
+	}
+
+	// Mean resultant length R is 1 for identical directions and 0 when they
+	// cancel out; the circular standard deviation is sqrt(-2 ln R).
+	r := math.Hypot(sinSum, cosSum) / total
+	if r <= 0 {
+		return mean, DegreesHalfCircle
+	}
+	stdDev = math.Sqrt(math.Max(0, -2*math.Log(math.Min(r, 1)))) * DegreesHalfCircle / math.Pi
+	return mean, math.Min(stdDev, DegreesHalfCircle)
+}
+
 func angleDiff(a, b float64) float64 {
 	d := math.Abs(a - b)
 	if d > DegreesHalfCircle {
@@ -231,37 +508,47 @@ func angleDiff(a, b float64) float64 {
 
 // CalcFlyabilityScore calculates a 1-5 star rating using TuningConfig.
 func CalcFlyabilityScore(h *HourlyData, site Site, gradientRating string, thermalRating string, tc *TuningConfig) int {
+	return CalcFlyabilityScoreBreakdown(h, site, gradientRating, thermalRating, tc).Score
+}
+
+// CalcFlyabilityScoreBreakdown calculates the flyability score and explains
+// it: the base score, each bonus or penalty with its reason and tuning key,
+// and the raw sum before rounding and clamping.
+func CalcFlyabilityScoreBreakdown(h *HourlyData, site Site, gradientRating string, thermalRating string, tc *TuningConfig) ScoreBreakdown {
 	s := tc.Scoring
 
-	score := s.BaseScore
+	b := ScoreBreakdown{Base: s.BaseScore, Contributions: []ScoreContribution{}}
+	add := func(reason, key string, value float64) {
+		b.Contributions = append(b.Contributions, ScoreContribution{Reason: reason, Key: key, Value: value})
+	}
 
 	// Wind speed
 	ws := h.WindSpeed
 	switch {
 	case ws >= tc.Wind.IdealMin && ws <= tc.Wind.IdealMax:
-		score += s.WindIdealBonus
+		add(ReasonWindIdeal, "scoring.wind_ideal_bonus", s.WindIdealBonus)
 	case ws >= tc.Wind.AcceptableMin && ws <= tc.Wind.AcceptableMax:
-		score += s.WindAcceptableBonus
+		add(ReasonWindAcceptable, "scoring.wind_acceptable_bonus", s.WindAcceptableBonus)
 	case ws > tc.Wind.DangerousMax:
-		score += s.WindDangerPenalty
+		add(ReasonWindDangerous, "scoring.wind_danger_penalty", s.WindDangerPenalty)
 	case ws > tc.Wind.AcceptableMax:
-		score += s.WindHighPenalty
+		add(ReasonWindHigh, "scoring.wind_high_penalty", s.WindHighPenalty)
 	}
 
 	// Wind direction
 	if isInWindRange(h.WindDirection, site.WindMin, site.WindMax) {
-		score += s.DirOnBonus
+		add(ReasonDirOn, "scoring.dir_on_bonus", s.DirOnBonus)
 	} else {
 		// How far outside the acceptable range?
 		distFromRange := distanceFromWindRange(h.WindDirection, site.WindMin, site.WindMax)
 		switch {
 		case distFromRange > WindDirFarOffAngle:
-			score += s.DirOffPenalty // e.g. -2.0
+			add(ReasonDirFarOff, "scoring.dir_off_penalty", s.DirOffPenalty) // e.g. -2.0
 		case distFromRange > WindDirModerateOffAngle:
-			score -= 1.0
+			add(ReasonDirModerateOff, "", -1.0)
 		case distFromRange > WindDirMarginalAngle:
-			score -= 0.5
-		// Within 20° of range edge — marginal, no penalty
+			add(ReasonDirMarginal, "", -0.5)
+			// Within 20° of range edge — marginal, no penalty
 		}
 	}
 
@@ -269,46 +556,52 @@ func CalcFlyabilityScore(h *HourlyData, site Site, gradientRating string, therma
 	if h.WindSpeed > 0 {
 		gustFactor := h.WindGusts / h.WindSpeed
 		if gustFactor > tc.Wind.DangerousGustFactor {
-			score += s.GustHighPenalty
+			add(ReasonGustHigh, "scoring.gust_high_penalty", s.GustHighPenalty)
 		} else if gustFactor > tc.Wind.MaxGustFactor {
-			score += s.GustMedPenalty
+			add(ReasonGustMed, "scoring.gust_med_penalty", s.GustMedPenalty)
 		}
 	}
 
 	// Wind gradient (NEW - was missing from scoring)
 	switch gradientRating {
 	case GradientHigh:
-		score += s.GradientHighPenalty
+		add(ReasonGradientHigh, "scoring.gradient_high_penalty", s.GradientHighPenalty)
 	case GradientMedium:
-		score += s.GradientMedPenalty
+		add(ReasonGradientMed, "scoring.gradient_med_penalty", s.GradientMedPenalty)
 	}
 
 	// Rain
 	if h.Precipitation > 0 {
-		score += s.RainPenalty
+		add(ReasonRain, "scoring.rain_penalty", s.RainPenalty)
 	} else if h.PrecipitationProbability > PrecipProbHighThreshold {
-		score += s.RainProbPenalty
+		add(ReasonRainLikely, "scoring.rain_prob_penalty", s.RainProbPenalty)
 	} else if h.PrecipitationProbability > PrecipProbLowThreshold {
-		score -= 0.25
+		add(ReasonRainPossible, "", -0.25)
 	}
 
 	// Thermals bonus
 	if h.CAPE >= tc.Thermal.CAPEModerate && h.CAPE < tc.Thermal.CAPEExtreme {
-		score += s.CAPEBonus
+		add(ReasonCAPE, "scoring.cape_bonus", s.CAPEBonus)
 	}
 	if thermalRating == ThermalStrong || thermalRating == ThermalModerate {
-		score += s.ThermalStrongBonus
+		add(ReasonThermals, "scoring.thermal_strong_bonus", s.ThermalStrongBonus)
 	}
 
-	// Clamp 1-5
-	result := int(math.Round(score))
-	if result < ScoreMin {
-		result = ScoreMin
-	}
-	if result > ScoreMax {
-		result = ScoreMax
+	b.Raw = b.Base
+	for _, c := range b.Contributions {
+		b.Raw += c.Value
 	}
-	return result
+
+	// Clamp 1-5
+	b.Exact = ClampScore(b.Raw)
+	b.Score = int(math.Round(b.Exact))
+	return b
+}
+
+// ClampScore limits a raw flyability score to the ScoreMin-ScoreMax range
+// without rounding it.
+func ClampScore(raw float64) float64 {
+	return math.Min(math.Max(raw, ScoreMin), ScoreMax)
 }
 
 func isInWindRange(dir float64, min, max int) bool {
@@ -333,17 +626,18 @@ func distanceFromWindRange(dir float64, min, max int) float64 {
 	return dMax
 }
 
-// CalcXCPotential rates cross-country potential.
-func CalcXCPotential(cape float64, cloudbaseFt int, windSpeed float64, thermalRating string, tc *TuningConfig) string {
+// CalcXCPotential rates cross-country potential. ceilingFt is the usable
+// thermal ceiling above launch: cloudbase, or lower when thermals are capped.
+func CalcXCPotential(cape float64, ceilingFt int, windSpeed float64, thermalRating string, tc *TuningConfig) string {
 	score := 0
 	if cape >= tc.Thermal.CAPEStrong {
 		score += 2
 	} else if cape >= tc.Thermal.CAPEModerate {
 		score++
 	}
-	if cloudbaseFt >= tc.XC.GoodCloudbaseFt {
+	if ceilingFt >= tc.XC.GoodCloudbaseFt {
 		score += 2
-	} else if cloudbaseFt >= tc.XC.MinCloudbaseFt {
+	} else if ceilingFt >= tc.XC.MinCloudbaseFt {
 		score++
 	}
 	if windSpeed >= tc.XC.MinWindSpeed && windSpeed <= tc.XC.MaxWindSpeed {
@@ -370,32 +664,108 @@ func CalcXCPotential(cape float64, cloudbaseFt int, windSpeed float64, thermalRa
 	}
 }
 
-// ComputeHourlyMetrics computes all paragliding metrics for one hour.
+// ComputeHourlyMetrics computes all paragliding metrics for one hour of mph
+// data using the DefaultScorer.
 func ComputeHourlyMetrics(h *HourlyData, site Site, tc *TuningConfig) HourlyMetrics {
+	return ComputeHourlyMetricsWithScorer(h, site, tc, DefaultScorer{}, "mph")
+}
+
+// ComputeHourlyMetricsWithScorer computes all paragliding metrics for one
+// hour, taking the flyability score and XC potential from the given scorer.
+// Storm risk still caps the score whichever scorer is used. units is the
+// data's wind speed unit, used to label the warnings.
+//
+// For a site with several launches every launch is scored, and the metrics
+// describe the best one.
+func ComputeHourlyMetricsWithScorer(h *HourlyData, site Site, tc *TuningConfig, scorer Scorer, units string) HourlyMetrics {
+	stormRisk := CalcStormRisk(h, tc)
+	hc, launch, launchScores, breakdown := bestLaunch(h, site, tc, scorer, stormRisk)
+	site, cb := hc.Site, hc.Cloudbase
+
+	m := HourlyMetrics{
+		Time:                h.Time,
+		WindSpeed:           h.WindSpeed,
+		WindDirection:       h.WindDirection,
+		WindDirStr:          DegreesToCompass(h.WindDirection),
+		WindGusts:           h.WindGusts,
+		WindGradient:        hc.WindGradient,
+		WindGradientDiff:    hc.WindGradientDiff,
+		ThermalRating:       hc.ThermalRating,
+		CAPE:                h.CAPE,
+		CAPERating:          CalcCAPERating(h.CAPE, tc),
+		StormRisk:           stormRisk,
+		LiftedIndex:         h.LiftedIndex,
+		CIN:                 h.CIN,
+		BoundaryLayerFt:     int(math.Round(h.BoundaryLayerHeight * MetersToFeet)),
+		CloudbaseFt:         cb.CloudbaseFt,
+		CloudbaseAMSLFt:     cb.CloudbaseAMSLFt,
+		ThermalTopFt:        cb.ThermalTopFt,
+		ThermalTopAMSLFt:    cb.ThermalTopAMSLFt,
+		CloudbaseMethod:     cb.Method,
+		InversionBaseFt:     cb.InversionBaseFt,
+		InversionBaseAMSLFt: cb.InversionBaseAMSLFt,
+		InversionStrengthC:  cb.InversionStrengthC,
+		ThermalsCapped:      cb.ThermalsCapped,
+		StableLayers:        cb.StableLayers,
+		CloudCover:          h.CloudCover,
+		Precipitation:       h.Precipitation,
+		PrecipProb:          h.PrecipitationProbability,
+		OrographicLift:      CalcOrographicLift(h.WindDirection, h.WindSpeed, site.Aspect, tc),
+		FlyabilityScore:     breakdown.Score,
+		FlyabilityExact:     breakdown.Exact,
+		ScoreBreakdown:      breakdown,
+		ThermalStrengthMS:   CalcThermalStrength(h, cb.ThermalTopFt, tc),
+		XCPotential:         scorer.XCPotential(hc),
+		FreezingLevel:       h.FreezingLevelHeight * MetersToFeet,
+		IsDay:               h.IsDay == 1,
+		Launch:              launch,
+		LaunchScores:        launchScores,
+		PressureLevels:      h.PressureLevels,
+	}
+	m.Warnings = CalcWarnings(h, &m, site, tc, units)
+	return m
+}
+
+// capForStormRisk caps a score breakdown for the hour's storm risk.
+func capForStormRisk(b *ScoreBreakdown, stormRisk string, tc *TuningConfig) {
+	if capped := CapScoreForStormRisk(b.Score, stormRisk, tc); capped != b.Score {
+		b.Score = capped
+		b.Exact = math.Min(b.Exact, float64(capped))
+		b.CappedBy = fmt.Sprintf(ReasonStormCap, stormRisk)
+	}
+}
+
+// bestLaunch narrows a site to its best launch for the hour, ranked on the
+// unrounded score, and returns the launch's context, name and storm-capped
+// score. For sites with several launches it also returns every launch's score.
+func bestLaunch(h *HourlyData, site Site, tc *TuningConfig, scorer Scorer, stormRisk string) (*HourContext, string, []LaunchScore, ScoreBreakdown) {
 	gradientDiff, gradientRating := CalcWindGradient(h.WindSpeed, h.PressureLevels, tc)
-	thermalRating := CalcThermalRating(h.CAPE, h.PressureLevels, tc)
-	cloudbase := CalcCloudbaseFt(h.Temperature, h.DewPoint, tc)
-
-	return HourlyMetrics{
-		Time:             h.Time,
-		WindSpeed:        h.WindSpeed,
-		WindDirection:    h.WindDirection,
-		WindDirStr:       DegreesToCompass(h.WindDirection),
-		WindGusts:        h.WindGusts,
-		WindGradient:     gradientRating,
-		WindGradientDiff: gradientDiff,
-		ThermalRating:    thermalRating,
-		CAPE:             h.CAPE,
-		CAPERating:       CalcCAPERating(h.CAPE, tc),
-		CloudbaseFt:      cloudbase,
-		CloudCover:       h.CloudCover,
-		Precipitation:    h.Precipitation,
-		PrecipProb:       h.PrecipitationProbability,
-		OrographicLift:   CalcOrographicLift(h.WindDirection, h.WindSpeed, site.Aspect, tc),
-		FlyabilityScore:  CalcFlyabilityScore(h, site, gradientRating, thermalRating, tc),
-		XCPotential:      CalcXCPotential(h.CAPE, cloudbase, h.WindSpeed, thermalRating, tc),
-		FreezingLevel:    h.FreezingLevelHeight * MetersToFeet,
-		IsDay:            h.IsDay == 1,
-		PressureLevels:   h.PressureLevels,
+	score := func(s Site) (*HourContext, ScoreBreakdown) {
+		hc := newHourContext(h, s, tc, gradientDiff, gradientRating)
+		b := scorer.Score(hc)
+		capForStormRisk(&b, stormRisk, tc)
+		return hc, b
+	}
+
+	switch len(site.Launches) {
+	case 0:
+		hc, b := score(site)
+		return hc, "", nil, b
+	case 1:
+		hc, b := score(site.ForLaunch(site.Launches[0]))
+		return hc, site.Launches[0].Name, nil, b
+	}
+
+	scores := make([]LaunchScore, len(site.Launches))
+	var bestHC *HourContext
+	var bestB ScoreBreakdown
+	best := 0
+	for i, l := range site.Launches {
+		hc, b := score(site.ForLaunch(l))
+		scores[i] = LaunchScore{Launch: l.Name, FlyabilityScore: b.Score, FlyabilityExact: b.Exact}
+		if i == 0 || b.Exact > scores[best].FlyabilityExact {
+			best, bestHC, bestB = i, hc, b
+		}
 	}
+	return bestHC, site.Launches[best].Name, scores, bestB
 }
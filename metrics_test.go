@@ -1,6 +1,9 @@
 package pgforecast
 
 import (
+	"encoding/json"
+	"math"
+	"strings"
 	"testing"
 )
 
@@ -436,3 +439,387 @@ func TestDefaultTuningConfig(t *testing.T) {
 		t.Errorf("MinRealisticFt should be > 0, got %d", tc.Cloudbase.MinRealisticFt)
 	}
 }
+
+func TestCircularStats(t *testing.T) {
+	tests := []struct {
+		name       string
+		dirs       []float64
+		weights    []float64
+		wantMean   float64
+		wantStdMin float64
+		wantStdMax float64
+	}{
+		{"steady", []float64{225, 225, 225}, nil, 225, 0, 0.001},
+		{"across north", []float64{350, 10}, nil, 0, 9, 11},
+		{"speed weighted", []float64{90, 180}, []float64{3, 0}, 90, 0, 0.001},
+		{"zero weights fall back to equal", []float64{350, 10}, []float64{0, 0}, 0, 9, 11},
+		{"opposed", []float64{0, 180}, nil, 0, 180, 180},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mean, std := CircularStats(tt.dirs, tt.weights)
+			if tt.wantStdMax < 180 && angleDiff(mean, tt.wantMean) > 0.001 {
+				t.Errorf("mean = %v, want %v", mean, tt.wantMean)
+			}
+			if std < tt.wantStdMin || std > tt.wantStdMax {
+				t.Errorf("stdDev = %v, want %v-%v", std, tt.wantStdMin, tt.wantStdMax)
+			}
+		})
+	}
+}
+
+func TestCalcCloudbase(t *testing.T) {
+	tc := DefaultTuningConfig()
+	site := Site{Elevation: 150}
+	levels := func(t950 float64) []PressureLevel {
+		return []PressureLevel{
+			{Pressure: 1000, Temperature: 19, GeopotentialHeight: 100},
+			{Pressure: 950, Temperature: t950, GeopotentialHeight: 500},
+			{Pressure: 900, Temperature: 10, GeopotentialHeight: 1000},
+			{Pressure: 850, Temperature: 6, GeopotentialHeight: 1500},
+			{Pressure: 700, Temperature: -5, GeopotentialHeight: 3000},
+		}
+	}
+
+	t.Run("profile", func(t *testing.T) {
+		h := HourlyData{Temperature: 18, DewPoint: 10, PressureLevels: levels(14)}
+		cb := CalcCloudbase(&h, site, tc)
+		if cb.Method != CloudbaseMethodProfile {
+			t.Fatalf("Method = %q, want profile", cb.Method)
+		}
+		// 8°C spread ≈ 1000m ≈ 3200ft above launch
+		if cb.CloudbaseFt < 2900 || cb.CloudbaseFt > 3500 {
+			t.Errorf("CloudbaseFt = %d, want 2900-3500", cb.CloudbaseFt)
+		}
+		if want := cb.CloudbaseFt + 492; cb.CloudbaseAMSLFt != want {
+			t.Errorf("CloudbaseAMSLFt = %d, want %d (launch 150m = 492ft)", cb.CloudbaseAMSLFt, want)
+		}
+		if cb.ThermalTopFt != cb.CloudbaseFt {
+			t.Errorf("ThermalTopFt = %d, want thermals to reach cloudbase (%d)", cb.ThermalTopFt, cb.CloudbaseFt)
+		}
+	})
+
+	t.Run("inversion caps thermals", func(t *testing.T) {
+		h := HourlyData{Temperature: 18, DewPoint: 10, PressureLevels: levels(20)}
+		cb := CalcCloudbase(&h, site, tc)
+		if cb.ThermalTopFt >= cb.CloudbaseFt || cb.ThermalTopFt > 1000 {
+			t.Errorf("ThermalTopFt = %d, want capped well below cloudbase %d", cb.ThermalTopFt, cb.CloudbaseFt)
+		}
+		if !cb.ThermalsCapped || cb.InversionBaseFt != 0 || cb.InversionStrengthC < 1.5 {
+			t.Errorf("inversion = base %dft, +%.1f°C, capped %v; want surface-based, ~+2°C, capped",
+				cb.InversionBaseFt, cb.InversionStrengthC, cb.ThermalsCapped)
+		}
+	})
+
+	t.Run("elevated inversion", func(t *testing.T) {
+		h := HourlyData{Temperature: 18, DewPoint: 10, PressureLevels: []PressureLevel{
+			{Pressure: 1000, Temperature: 19, GeopotentialHeight: 100},
+			{Pressure: 950, Temperature: 15, GeopotentialHeight: 500},
+			{Pressure: 900, Temperature: 16, GeopotentialHeight: 1000},
+			{Pressure: 850, Temperature: 12, GeopotentialHeight: 1500},
+			{Pressure: 700, Temperature: -5, GeopotentialHeight: 3000},
+		}}
+		cb := CalcCloudbase(&h, site, tc)
+		if !cb.ThermalsCapped {
+			t.Fatal("ThermalsCapped = false, want the 500-1000m inversion to cap thermals")
+		}
+		// Inversion base 500m AMSL = 1640ft; 350m above launch = 1148ft.
+		if cb.InversionBaseAMSLFt != 1640 || cb.ThermalTopFt != 1148 {
+			t.Errorf("InversionBaseAMSLFt = %d, ThermalTopFt = %d; want 1640 and 1148", cb.InversionBaseAMSLFt, cb.ThermalTopFt)
+		}
+		if math.Abs(cb.InversionStrengthC-1) > 0.01 {
+			t.Errorf("InversionStrengthC = %.2f, want 1", cb.InversionStrengthC)
+		}
+		if len(cb.StableLayers) != 1 || !cb.StableLayers[0].Inversion || cb.StableLayers[0].LapseRate >= 0 {
+			t.Errorf("StableLayers = %+v, want one inversion", cb.StableLayers)
+		}
+	})
+
+	t.Run("surface fallback", func(t *testing.T) {
+		h := HourlyData{Temperature: 18, DewPoint: 10}
+		cb := CalcCloudbase(&h, site, tc)
+		if cb.Method != CloudbaseMethodSurface {
+			t.Errorf("Method = %q, want surface", cb.Method)
+		}
+		if want := CalcCloudbaseFt(18, 10, tc); cb.CloudbaseFt != want {
+			t.Errorf("CloudbaseFt = %d, want %d", cb.CloudbaseFt, want)
+		}
+	})
+}
+
+func TestCapThermalRating(t *testing.T) {
+	tc := DefaultTuningConfig()
+	tests := []struct {
+		rating  string
+		ceiling int
+		want    string
+	}{
+		{ThermalStrong, 4000, ThermalStrong},
+		{ThermalStrong, 1000, ThermalWeak},
+		{ThermalWeak, 1000, ThermalWeak},
+		{ThermalExtreme, 200, ThermalNone},
+		{ThermalNone, 4000, ThermalNone},
+	}
+	for _, tt := range tests {
+		if got := CapThermalRating(tt.rating, tt.ceiling, tc); got != tt.want {
+			t.Errorf("CapThermalRating(%s, %dft) = %s, want %s", tt.rating, tt.ceiling, got, tt.want)
+		}
+	}
+}
+
+func TestCalcThermalStrength(t *testing.T) {
+	tc := DefaultTuningConfig()
+	h := HourlyData{Temperature: 20, ShortwaveRadiation: 700, PressureMSL: 1015}
+
+	// 210 W/m² sensible heat over a 1500m layer gives W* ≈ 2.1 m/s, less 1 m/s sink.
+	got := CalcThermalStrength(&h, 4921, tc)
+	if got < 0.9 || got > 1.3 {
+		t.Errorf("CalcThermalStrength = %.2f m/s, want ~1.1", got)
+	}
+
+	deeper := CalcThermalStrength(&h, 8000, tc)
+	if deeper <= got {
+		t.Errorf("deeper layer climb = %.2f, want stronger than %.2f", deeper, got)
+	}
+
+	if got := CalcThermalStrength(&h, 0, tc); got != 0 {
+		t.Errorf("capped at launch = %.2f, want 0", got)
+	}
+	night := HourlyData{Temperature: 10}
+	if got := CalcThermalStrength(&night, 4000, tc); got != 0 {
+		t.Errorf("no sun = %.2f, want 0", got)
+	}
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestCalcHourThermalRating(t *testing.T) {
+	tc := DefaultTuningConfig()
+	levels := []PressureLevel{
+		{Pressure: 925, Temperature: 15, GeopotentialHeight: 750},
+		{Pressure: 700, Temperature: -4.1, GeopotentialHeight: 3000},
+	}
+	tests := []struct {
+		name string
+		h    HourlyData
+		want string
+	}{
+		{"no convective data", HourlyData{CAPE: 1500}, ThermalStrong},
+		{"heavy CIN", HourlyData{CAPE: 1500, CIN: 200}, ThermalWeak},
+		{"moderate CIN", HourlyData{CAPE: 1500, CIN: 60}, ThermalModerate},
+		{"unstable LI", HourlyData{CAPE: 400, LiftedIndex: -3}, ThermalStrong},
+		{"stable LI", HourlyData{CAPE: 400, LiftedIndex: 5}, ThermalWeak},
+		{"shallow boundary layer", HourlyData{CAPE: 1500, BoundaryLayerHeight: 300}, ThermalModerate},
+		{"deep boundary layer", HourlyData{CAPE: 400, BoundaryLayerHeight: 2000}, ThermalStrong},
+		{"little sunshine", HourlyData{CAPE: 1500, SunshineDuration: floatPtr(600)}, ThermalModerate},
+		{"no sunshine", HourlyData{CAPE: 1500, SunshineDuration: floatPtr(0)}, ThermalModerate},
+		{"full sunshine", HourlyData{CAPE: 1500, SunshineDuration: floatPtr(3600)}, ThermalStrong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 925-700 lapse rate 8.5°C/km earns one lapse-rate point on top of CAPE.
+			tt.h.PressureLevels = levels
+			if got := CalcHourThermalRating(&tt.h, tc); got != tt.want {
+				t.Errorf("CalcHourThermalRating = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalcCloudbase_BoundaryLayer(t *testing.T) {
+	tc := DefaultTuningConfig()
+	h := HourlyData{Temperature: 18, DewPoint: 10, BoundaryLayerHeight: 500}
+	cb := CalcCloudbase(&h, Site{}, tc)
+	if cb.ThermalTopFt != 1640 {
+		t.Errorf("ThermalTopFt = %d, want capped at the 500m boundary layer (1640ft)", cb.ThermalTopFt)
+	}
+	if cb.CloudbaseFt <= cb.ThermalTopFt {
+		t.Errorf("CloudbaseFt = %d, want unaffected by the boundary layer", cb.CloudbaseFt)
+	}
+}
+
+func TestCalcStormRisk(t *testing.T) {
+	tc := DefaultTuningConfig()
+	moist := []PressureLevel{
+		{Pressure: 700, RelativeHumidity: 80},
+		{Pressure: 600, RelativeHumidity: 75},
+		{Pressure: 500, RelativeHumidity: 70},
+	}
+	tests := []struct {
+		name string
+		h    HourlyData
+		want string
+	}{
+		{"stable", HourlyData{CAPE: 300, LiftedIndex: 2}, StormNone},
+		{"moist but stable", HourlyData{CAPE: 300, PrecipitationProbability: 80, PressureLevels: moist}, StormNone},
+		{"moderate CAPE", HourlyData{CAPE: 1200}, StormLow},
+		{"unstable and moist", HourlyData{CAPE: 1200, LiftedIndex: -4, PressureLevels: moist}, StormModerate},
+		{"unstable with showers", HourlyData{CAPE: 2500, LiftedIndex: -4, PrecipitationProbability: 60}, StormHigh},
+		{"capped by CIN", HourlyData{CAPE: 1200, LiftedIndex: -4, CIN: 200, PressureLevels: moist}, StormLow},
+		{"thunderstorm code", HourlyData{WeatherCode: WMOThunderstormHail}, StormHigh},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalcStormRisk(&tt.h, tc); got != tt.want {
+				t.Errorf("CalcStormRisk() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeHourlyMetrics_StormCapsScore(t *testing.T) {
+	tc := DefaultTuningConfig()
+	site := Site{WindMin: 200, WindMax: 250, Aspect: 225}
+	h := HourlyData{WindSpeed: 12, WindDirection: 225, WindGusts: 15, Temperature: 22, DewPoint: 14, CAPE: 1200, IsDay: 1}
+
+	if m := ComputeHourlyMetrics(&h, site, tc); m.StormRisk != StormLow || m.FlyabilityScore < 3 {
+		t.Fatalf("score = %d, risk = %q; want a flyable Low-risk hour", m.FlyabilityScore, m.StormRisk)
+	}
+	h.LiftedIndex = -4
+	h.PrecipitationProbability = 45
+	if m := ComputeHourlyMetrics(&h, site, tc); m.StormRisk != StormModerate || m.FlyabilityScore != tc.Storm.ModerateMaxScore {
+		t.Errorf("score = %d, risk = %q; want %d at Moderate risk", m.FlyabilityScore, m.StormRisk, tc.Storm.ModerateMaxScore)
+	}
+	h.WeatherCode = WMOThunderstorm
+	if m := ComputeHourlyMetrics(&h, site, tc); m.FlyabilityScore != ScoreMin {
+		t.Errorf("score = %d during a thunderstorm, want %d", m.FlyabilityScore, ScoreMin)
+	}
+}
+
+func TestCalcWarnings(t *testing.T) {
+	tc := DefaultTuningConfig()
+	site := Site{WindMin: 200, WindMax: 250, Aspect: 225, Elevation: 200}
+
+	calm := HourlyData{WindSpeed: 12, WindDirection: 225, WindGusts: 15, Temperature: 20, DewPoint: 8, Visibility: 30000}
+	m := ComputeHourlyMetrics(&calm, site, tc)
+	if m.Warnings == nil || len(m.Warnings) != 0 {
+		t.Fatalf("Warnings = %#v, want an empty non-nil list", m.Warnings)
+	}
+
+	rough := HourlyData{
+		WindSpeed: 15, WindDirection: 45, WindGusts: 32,
+		Temperature: 10, DewPoint: 10, Precipitation: 0.6, Visibility: 1000,
+		PressureLevels: []PressureLevel{
+			{Pressure: 1000, WindSpeed: 15, GeopotentialHeight: 100},
+			{Pressure: 850, WindSpeed: 45, GeopotentialHeight: 1500},
+			{Pressure: 700, WindSpeed: 60, GeopotentialHeight: 3000},
+			{Pressure: 500, WindSpeed: 90, GeopotentialHeight: 5500},
+		},
+	}
+	m = ComputeHourlyMetrics(&rough, site, tc)
+	got := make(map[string]Warning)
+	for _, w := range m.Warnings {
+		got[w.Type] = w
+	}
+	for _, typ := range []string{WarnGusts, WarnWindAloft, WarnGradient, WarnDirection, WarnRotor, WarnRain, WarnCloudbase, WarnVisibility} {
+		w, ok := got[typ]
+		if !ok {
+			t.Errorf("missing %s warning; got %+v", typ, m.Warnings)
+			continue
+		}
+		if w.Severity != SeverityDanger || w.Message == "" {
+			t.Errorf("%s warning = %+v, want a Danger with a message", typ, w)
+		}
+	}
+	// 500hPa is above the levels checked for wind aloft.
+	if w := got[WarnWindAloft]; !strings.Contains(w.Message, "700hPa") {
+		t.Errorf("wind aloft = %q, want the 700hPa level", w.Message)
+	}
+	if _, ok := got[WarnWind]; ok {
+		t.Error("15mph surface wind should not be flagged as strong")
+	}
+	if w := got[WarnGusts]; !strings.HasPrefix(w.Message, "Gusts to 32mph ") {
+		t.Errorf("gusts = %q, want the speed in mph", w.Message)
+	}
+
+	// Other units label the speeds with the forecast's unit.
+	for _, w := range CalcWarnings(&rough, &m, site, tc, "knots") {
+		if (w.Type == WarnGusts || w.Type == WarnWindAloft) && !strings.Contains(w.Message, "knots") {
+			t.Errorf("%s warning = %q, want the speed in knots", w.Type, w.Message)
+		}
+	}
+}
+
+func TestCalcFlyabilityScoreBreakdown(t *testing.T) {
+	tc := DefaultTuningConfig()
+	site := Site{WindMin: 200, WindMax: 250}
+	h := HourlyData{WindSpeed: 12, WindDirection: 310, WindGusts: 22, CAPE: 600, PrecipitationProbability: 40}
+
+	b := CalcFlyabilityScoreBreakdown(&h, site, GradientMedium, ThermalModerate, tc)
+	if b.Base != tc.Scoring.BaseScore {
+		t.Errorf("Base = %v, want %v", b.Base, tc.Scoring.BaseScore)
+	}
+	sum := b.Base
+	for _, c := range b.Contributions {
+		sum += c.Value
+	}
+	if math.Abs(sum-b.Raw) > 1e-9 {
+		t.Errorf("Raw = %v, want base + contributions = %v", b.Raw, sum)
+	}
+	if got := CalcFlyabilityScore(&h, site, GradientMedium, ThermalModerate, tc); b.Score != got {
+		t.Errorf("Score = %d, CalcFlyabilityScore = %d", b.Score, got)
+	}
+
+	// Every key must name a real tuning value, so the frontend can match it.
+	raw, _ := json.Marshal(tc)
+	var sections map[string]map[string]any
+	json.Unmarshal(raw, &sections)
+	reasons := make(map[string]bool)
+	for _, c := range b.Contributions {
+		reasons[c.Reason] = true
+		if c.Key == "" {
+			continue
+		}
+		section, key, _ := strings.Cut(c.Key, ".")
+		if _, ok := sections[section][key]; !ok {
+			t.Errorf("contribution %q has unknown key %q", c.Reason, c.Key)
+		}
+	}
+	for _, want := range []string{ReasonWindIdeal, ReasonDirModerateOff, ReasonGustMed, ReasonGradientMed, ReasonRainPossible, ReasonCAPE, ReasonThermals} {
+		if !reasons[want] {
+			t.Errorf("breakdown missing %q; got %+v", want, b.Contributions)
+		}
+	}
+}
+
+func TestComputeHourlyMetrics_BreakdownCappedByStorm(t *testing.T) {
+	tc := DefaultTuningConfig()
+	h := HourlyData{WindSpeed: 12, WindDirection: 225, WindGusts: 14, Temperature: 20, DewPoint: 10, WeatherCode: WMOThunderstorm}
+	m := ComputeHourlyMetrics(&h, Site{WindMin: 200, WindMax: 250}, tc)
+	if m.ScoreBreakdown.Score != m.FlyabilityScore || m.ScoreBreakdown.CappedBy == "" {
+		t.Errorf("breakdown = %+v, want score %d capped by storm risk", m.ScoreBreakdown, m.FlyabilityScore)
+	}
+	if m.ScoreBreakdown.Raw <= float64(m.FlyabilityScore) {
+		t.Errorf("Raw = %v, want above the capped score %d", m.ScoreBreakdown.Raw, m.FlyabilityScore)
+	}
+}
+
+func TestComputeHourlyMetrics_BestLaunch(t *testing.T) {
+	tc := DefaultTuningConfig()
+	site := Site{Launches: []Launch{
+		{Name: "North", WindMin: 340, WindMax: 20, BestDir: 0, Aspect: 0},
+		{Name: "South", WindMin: 160, WindMax: 200, BestDir: 180, Aspect: 180},
+	}}
+	h := HourlyData{WindSpeed: 12, WindDirection: 180, WindGusts: 14, Temperature: 20, DewPoint: 10}
+
+	m := ComputeHourlyMetrics(&h, site, tc)
+	if m.Launch != "South" {
+		t.Errorf("Launch = %q, want South", m.Launch)
+	}
+	if len(m.LaunchScores) != 2 || m.LaunchScores[1].FlyabilityExact <= m.LaunchScores[0].FlyabilityExact {
+		t.Errorf("LaunchScores = %+v, want South above North", m.LaunchScores)
+	}
+	if m.FlyabilityExact != m.LaunchScores[1].FlyabilityExact {
+		t.Errorf("FlyabilityExact = %v, want the best launch's %v", m.FlyabilityExact, m.LaunchScores[1].FlyabilityExact)
+	}
+	for _, w := range m.Warnings {
+		if w.Type == WarnRotor || w.Type == WarnDirection {
+			t.Errorf("unexpected %s warning for the South launch: %s", w.Type, w.Message)
+		}
+	}
+
+	single := ComputeHourlyMetrics(&h, Site{WindMin: 160, WindMax: 200, Aspect: 180}, tc)
+	if single.Launch != "" || single.LaunchScores != nil {
+		t.Errorf("single-launch site got launch %q, scores %+v", single.Launch, single.LaunchScores)
+	}
+}
@@ -0,0 +1,57 @@
+package pgforecast
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ModelAuto selects Open-Meteo's default "best match" blend of models.
+const ModelAuto = "auto"
+
+// ErrUnknownModel is returned when a weather model name is not recognised.
+var ErrUnknownModel = errors.New("unknown weather model")
+
+// WeatherModel describes a numerical weather prediction model available from Open-Meteo.
+type WeatherModel struct {
+	Name        string `json:"name"`        // short name used on the CLI, e.g. "ecmwf"
+	OpenMeteoID string `json:"open_meteo"`  // value sent in the Open-Meteo "models" parameter
+	Description string `json:"description"` // human-readable model name
+}
+
+// WeatherModels lists the supported weather models in display order.
+var WeatherModels = []WeatherModel{
+	{Name: ModelAuto, OpenMeteoID: "best_match", Description: "Open-Meteo best match"},
+	{Name: "gfs", OpenMeteoID: "gfs_seamless", Description: "NOAA GFS"},
+	{Name: "ecmwf", OpenMeteoID: "ecmwf_ifs025", Description: "ECMWF IFS 0.25°"},
+	{Name: "icon", OpenMeteoID: "icon_seamless", Description: "DWD ICON"},
+	{Name: "icon-d2", OpenMeteoID: "icon_d2", Description: "DWD ICON-D2"},
+	{Name: "ukmo", OpenMeteoID: "ukmo_seamless", Description: "Met Office UKMO"},
+	{Name: "ukv", OpenMeteoID: "ukmo_uk_deterministic_2km", Description: "Met Office UKV 2km"},
+	{Name: "arome", OpenMeteoID: "meteofrance_arome_france", Description: "Météo-France AROME"},
+	{Name: "arpege", OpenMeteoID: "meteofrance_arpege_europe", Description: "Météo-France ARPEGE"},
+	{Name: "gem", OpenMeteoID: "gem_seamless", Description: "CMC GEM"},
+}
+
+// ResolveModel looks up a weather model by its short name (case-insensitive).
+// An empty name resolves to ModelAuto.
+func ResolveModel(name string) (WeatherModel, error) {
+	if name == "" {
+		name = ModelAuto
+	}
+	for _, m := range WeatherModels {
+		if equalsCI(m.Name, name) {
+			return m, nil
+		}
+	}
+	return WeatherModel{}, fmt.Errorf("%w %q (valid: %s)", ErrUnknownModel, name, strings.Join(ModelNames(), ", "))
+}
+
+// ModelNames returns the short names of all supported weather models.
+func ModelNames() []string {
+	names := make([]string, len(WeatherModels))
+	for i, m := range WeatherModels {
+		names[i] = m.Name
+	}
+	return names
+}
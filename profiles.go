@@ -0,0 +1,238 @@
+package pgforecast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProfileClub is the pilot profile the default tuning is written for.
+const ProfileClub = "club"
+
+// ErrUnknownProfile is returned when a pilot profile name is not recognised.
+var ErrUnknownProfile = errors.New("unknown pilot profile")
+
+// PilotProfile adjusts the tuning config for a pilot skill level. Profiles
+// scale the configured limits rather than replacing them, so they work with
+// any wind units and on top of a customised config.
+type PilotProfile struct {
+	Name        string `json:"name"`        // short name used on the CLI, e.g. "student"
+	Description string `json:"description"` // human-readable summary
+	adjust      func(tc *TuningConfig)
+}
+
+// Apply returns a copy of tc adjusted for the profile.
+func (p PilotProfile) Apply(tc *TuningConfig) *TuningConfig {
+	out := *tc
+	if p.adjust != nil {
+		p.adjust(&out)
+	}
+	return &out
+}
+
+// PilotProfiles lists the pilot profiles from least to most experienced.
+var PilotProfiles = []PilotProfile{
+	{
+		Name:        "student",
+		Description: "Student: light, smooth wind and gentle thermals",
+		adjust: func(tc *TuningConfig) {
+			scaleWindLimits(tc, 0.75, 0.8)
+			scaleGustFactors(tc, 0.6)
+			tc.Scoring.GradientHighPenalty *= 1.5
+			tc.Scoring.GradientMedPenalty *= 2
+			tc.Scoring.ThermalStrongBonus = 0
+			tc.Thermal.CAPEExtreme *= 0.6
+		},
+	},
+	{
+		Name:        ProfileClub,
+		Description: "Club pilot: the default tuning",
+	},
+	{
+		Name:        "xc",
+		Description: "XC pilot: tolerates more wind and rewards strong thermals",
+		adjust: func(tc *TuningConfig) {
+			scaleWindLimits(tc, 1.1, 1.1)
+			scaleGustFactors(tc, 1.2)
+			tc.Scoring.ThermalStrongBonus *= 2
+			tc.Scoring.CAPEBonus *= 1.5
+		},
+	},
+	{
+		Name:        "competition",
+		Description: "Competition pilot: strong wind, gusts and gradient are manageable",
+		adjust: func(tc *TuningConfig) {
+			scaleWindLimits(tc, 1.2, 1.2)
+			scaleGustFactors(tc, 1.4)
+			tc.Scoring.GradientHighPenalty *= 0.5
+			tc.Scoring.GradientMedPenalty *= 0.5
+			tc.Scoring.ThermalStrongBonus *= 2
+			tc.Scoring.CAPEBonus *= 2
+			tc.Thermal.CAPEExtreme *= 1.2
+		},
+	},
+}
+
+// scaleWindLimits scales the upper wind limits: the ideal and acceptable
+// maxima by f, and the dangerous maximum by danger.
+func scaleWindLimits(tc *TuningConfig, f, danger float64) {
+	tc.Wind.IdealMax *= f
+	tc.Wind.AcceptableMax *= f
+	tc.Wind.DangerousMax *= danger
+}
+
+// scaleGustFactors scales how far the gust factors sit above 1 (steady wind).
+func scaleGustFactors(tc *TuningConfig, f float64) {
+	tc.Wind.MaxGustFactor = 1 + (tc.Wind.MaxGustFactor-1)*f
+	tc.Wind.DangerousGustFactor = 1 + (tc.Wind.DangerousGustFactor-1)*f
+}
+
+// ProfileTuning returns the tuning config for a site flown to a pilot
+// profile: SiteTuning, with the profile then scaling the site's effective
+// limits, so absolute site overrides do not undo it. An empty name applies no
+// profile. Like SiteTuning, the result must pass Validate.
+func ProfileTuning(site Site, tc *TuningConfig, profile string) (*TuningConfig, error) {
+	out, err := SiteTuning(site, tc)
+	if err != nil || profile == "" {
+		return out, err
+	}
+	p, err := ResolveProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	out = p.Apply(out)
+	if err := out.Validate(); err != nil {
+		if len(site.Tuning) > 0 {
+			return nil, fmt.Errorf("tuning for site %s with the %s profile: %w", site.Name, p.Name, err)
+		}
+		return nil, fmt.Errorf("%s profile: %w", p.Name, err)
+	}
+	return out, nil
+}
+
+// ResolveProfile looks up a pilot profile by name (case-insensitive). An empty
+// name resolves to ProfileClub.
+func ResolveProfile(name string) (PilotProfile, error) {
+	if name == "" {
+		name = ProfileClub
+	}
+	for _, p := range PilotProfiles {
+		if equalsCI(p.Name, name) {
+			return p, nil
+		}
+	}
+	return PilotProfile{}, fmt.Errorf("%w %q (valid: %s)", ErrUnknownProfile, name, strings.Join(ProfileNames(), ", "))
+}
+
+// ProfileNames returns the names of all pilot profiles.
+func ProfileNames() []string {
+	names := make([]string, len(PilotProfiles))
+	for i, p := range PilotProfiles {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// ProfileComparison holds one site's forecast scored for several pilot
+// profiles from the same weather data, aligned hour by hour.
+type ProfileComparison struct {
+	Site        Site                    `json:"site"`
+	Generated   time.Time               `json:"generated"`
+	Units       string                  `json:"units"`
+	Profiles    []PilotProfile          `json:"profiles"`
+	BestWindows map[string]string       `json:"best_windows"` // by profile name; empty when no hour scores 3+
+	Hours       []ProfileHourComparison `json:"hours"`
+}
+
+// ProfileHourScore holds one profile's score for a single hour.
+type ProfileHourScore struct {
+	Profile         string  `json:"profile"`
+	FlyabilityScore int     `json:"flyability_score"`
+	FlyabilityExact float64 `json:"flyability_score_exact"`
+	XCPotential     string  `json:"xc_potential"`
+}
+
+// ProfileHourComparison holds the weather for one hour and each profile's
+// score for it, in the order of ProfileComparison.Profiles.
+type ProfileHourComparison struct {
+	Time       time.Time          `json:"time"`
+	WindSpeed  float64            `json:"wind_speed"`
+	WindGusts  float64            `json:"wind_gusts"`
+	WindDirStr string             `json:"wind_dir_str"`
+	Scores     []ProfileHourScore `json:"scores"`
+}
+
+// CompareProfiles scores a site's forecast for each of the named pilot
+// profiles, fetching the weather once.
+func CompareProfiles(site Site, profiles []string, opts ForecastOptions) (*ProfileComparison, error) {
+	return CompareProfilesWithContext(context.Background(), site, profiles, opts)
+}
+
+// CompareProfilesWithContext is CompareProfiles with context support for cancellation.
+func CompareProfilesWithContext(ctx context.Context, site Site, profiles []string, opts ForecastOptions) (*ProfileComparison, error) {
+	if len(profiles) == 0 {
+		return nil, errors.New("comparison needs at least 1 profile")
+	}
+	resolved := make([]PilotProfile, len(profiles))
+	for i, name := range profiles {
+		p, err := ResolveProfile(name)
+		if err != nil {
+			return nil, err
+		}
+		resolved[i] = p
+	}
+
+	tc := opts.Tuning
+	if tc == nil {
+		tc = DefaultTuningConfig()
+	}
+
+	data, fetchedAt, fromCache, err := fetchWeather(ctx, site, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetching weather for %s: %w", site.Name, err)
+	}
+	fetched := fetchResult{data: data, fetchedAt: fetchedAt, fromCache: fromCache}
+
+	cmp := &ProfileComparison{
+		Site:        site,
+		Units:       opts.Units,
+		Profiles:    resolved,
+		BestWindows: make(map[string]string, len(resolved)),
+	}
+	for i, p := range resolved {
+		popts := opts
+		popts.Tuning = tc
+		popts.Profile = p.Name
+		f, err := buildForecast(ctx, site, popts, fetched)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", p.Name, err)
+		}
+		cmp.Generated = f.Generated
+		cmp.BestWindows[p.Name] = f.BestWindow
+
+		// Every profile sees the same hours, so the first one lays them out.
+		n := 0
+		for _, day := range f.DetailedDays {
+			for _, h := range day.Hours {
+				if i == 0 {
+					cmp.Hours = append(cmp.Hours, ProfileHourComparison{
+						Time:       h.Time,
+						WindSpeed:  h.WindSpeed,
+						WindGusts:  h.WindGusts,
+						WindDirStr: h.WindDirStr,
+					})
+				}
+				cmp.Hours[n].Scores = append(cmp.Hours[n].Scores, ProfileHourScore{
+					Profile:         p.Name,
+					FlyabilityScore: h.FlyabilityScore,
+					FlyabilityExact: h.FlyabilityExact,
+					XCPotential:     h.XCPotential,
+				})
+				n++
+			}
+		}
+	}
+	return cmp, nil
+}
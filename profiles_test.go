@@ -0,0 +1,136 @@
+package pgforecast
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveProfile(t *testing.T) {
+	p, err := ResolveProfile("XC")
+	if err != nil || p.Name != "xc" {
+		t.Errorf("ResolveProfile(XC) = %q, %v; want xc", p.Name, err)
+	}
+	if p, err := ResolveProfile(""); err != nil || p.Name != ProfileClub {
+		t.Errorf("ResolveProfile(\"\") = %q, %v; want %s", p.Name, err, ProfileClub)
+	}
+	if _, err := ResolveProfile("acro"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("ResolveProfile(acro) err = %v, want ErrUnknownProfile", err)
+	}
+}
+
+func TestPilotProfilesApply(t *testing.T) {
+	tc := DefaultTuningConfig()
+	want := *tc
+
+	prev := PilotProfile{Name: "none"}
+	var prevTC *TuningConfig
+	for _, p := range PilotProfiles {
+		got := p.Apply(tc)
+		if prevTC != nil {
+			if got.Wind.DangerousMax <= prevTC.Wind.DangerousMax {
+				t.Errorf("%s dangerous max %v should exceed %s's %v", p.Name, got.Wind.DangerousMax, prev.Name, prevTC.Wind.DangerousMax)
+			}
+			if got.Wind.MaxGustFactor <= prevTC.Wind.MaxGustFactor {
+				t.Errorf("%s max gust factor %v should exceed %s's %v", p.Name, got.Wind.MaxGustFactor, prev.Name, prevTC.Wind.MaxGustFactor)
+			}
+		}
+		prev, prevTC = p, got
+	}
+
+	if *tc != want {
+		t.Error("Apply modified the input config")
+	}
+	club, _ := ResolveProfile(ProfileClub)
+	if got := club.Apply(tc); *got != want {
+		t.Error("club profile should leave the config unchanged")
+	}
+}
+
+func TestCompareProfiles(t *testing.T) {
+	calls := 0
+	client := mockClient(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(bytes.NewReader([]byte(`{"hourly":{"time":["2026-06-01T12:00","2026-06-01T13:00"],` +
+				`"is_day":[1,1],"wind_speed_10m":[20,20],"wind_gusts_10m":[27,27],"wind_direction_10m":[225,225]}}`))),
+			Header: make(http.Header),
+		}, nil
+	})
+	site := Site{Name: "Test", Lat: 50.6, Lon: -2.3, Aspect: 225, WindMin: 200, WindMax: 250}
+	opts := ForecastOptions{HTTPClient: client, Timezone: "UTC", Units: "mph"}
+
+	cmp, err := CompareProfilesWithContext(context.Background(), site, []string{"student", "competition"}, opts)
+	if err != nil {
+		t.Fatalf("CompareProfiles: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("weather fetched %d times, want once for all profiles", calls)
+	}
+	if len(cmp.Hours) != 2 {
+		t.Fatalf("got %d hours, want 2", len(cmp.Hours))
+	}
+	for _, h := range cmp.Hours {
+		if len(h.Scores) != 2 || h.Scores[0].Profile != "student" || h.Scores[1].Profile != "competition" {
+			t.Fatalf("scores = %+v, want student then competition", h.Scores)
+		}
+		if h.Scores[0].FlyabilityExact >= h.Scores[1].FlyabilityExact {
+			t.Errorf("%s: student %.2f should score below competition %.2f in 20mph gusting 27",
+				h.Time.Format("15:04"), h.Scores[0].FlyabilityExact, h.Scores[1].FlyabilityExact)
+		}
+	}
+
+	if _, err := CompareProfiles(site, []string{"acro"}, opts); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("unknown profile err = %v, want ErrUnknownProfile", err)
+	}
+}
+
+func TestProfileTuningSiteOverrides(t *testing.T) {
+	// A coastal site like Barton-on-Sea: absolute wind limits above the
+	// defaults. Each profile must scale them rather than be overridden.
+	site := Site{Name: "Coastal", Aspect: 180, WindMin: 135, WindMax: 225,
+		Tuning: TuningOverrides{"wind": map[string]any{"ideal_min": 12, "ideal_max": 20, "acceptable_min": 10, "max_gust_factor": 1.3}}}
+	siteTC, err := SiteTuning(site, nil)
+	if err != nil {
+		t.Fatalf("SiteTuning: %v", err)
+	}
+	data := []HourlyData{{Time: time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC), IsDay: 1, WindSpeed: 18, WindGusts: 22, WindDirection: 180}}
+
+	for _, p := range PilotProfiles {
+		got, err := ProfileTuning(site, nil, p.Name)
+		if err != nil {
+			t.Errorf("%s: ProfileTuning: %v", p.Name, err)
+			continue
+		}
+		want := p.Apply(siteTC)
+		if got.Wind != want.Wind {
+			t.Errorf("%s: wind = %+v, want the site's limits scaled: %+v", p.Name, got.Wind, want.Wind)
+		}
+
+		opts := ForecastOptions{Timezone: "UTC", Profile: p.Name}
+		f, err := buildForecast(context.Background(), site, opts, fetchResult{data: data})
+		if err != nil {
+			t.Errorf("%s: buildForecast: %v", p.Name, err)
+			continue
+		}
+		if f.Tuning.Wind != want.Wind {
+			t.Errorf("%s: forecast wind tuning = %+v, want %+v", p.Name, f.Tuning.Wind, want.Wind)
+		}
+	}
+
+	// A profile can still scale a narrow site range out of order.
+	narrow := Site{Name: "Narrow", Tuning: TuningOverrides{"wind": map[string]any{"ideal_min": 15, "ideal_max": 19}}}
+	var tuningErr *TuningError
+	if _, err := ProfileTuning(narrow, nil, "student"); !errors.As(err, &tuningErr) || !strings.Contains(err.Error(), "student profile") {
+		t.Errorf("narrow site with student profile err = %v, want a TuningError naming the profile", err)
+	}
+	if _, err := ProfileTuning(site, nil, "acro"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("unknown profile err = %v, want ErrUnknownProfile", err)
+	}
+}
@@ -0,0 +1,128 @@
+package pgforecast
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ScorerDefault is the name of the built-in scoring model.
+const ScorerDefault = "default"
+
+// ErrUnknownScorer is returned when a scorer name is not recognised.
+var ErrUnknownScorer = errors.New("unknown scorer")
+
+// Scorer rates an hour for flying. Implementations replace the scoring model
+// (e.g. for hike-and-fly, speedflying or coastal soaring) while the rest of
+// the metrics — cloudbase, thermals, warnings, storm caps — stay the same.
+type Scorer interface {
+	// Name identifies the scorer, e.g. for the CLI's --scorer flag.
+	Name() string
+	// Score rates the hour, explaining how the score was reached.
+	// Breakdown.Score should be within ScoreMin-ScoreMax.
+	Score(hc *HourContext) ScoreBreakdown
+	// XCPotential rates the hour's cross-country potential (XCLow-XCEpic).
+	XCPotential(hc *HourContext) string
+}
+
+// HourContext is one hour at one launch, along with the metrics a Scorer
+// builds on. They are computed once per launch, so scorers need not repeat
+// the cloudbase parcel lift.
+type HourContext struct {
+	Data   *HourlyData
+	Site   Site // narrowed to the launch being scored
+	Tuning *TuningConfig
+
+	WindGradient     string // Low/Medium/High
+	WindGradientDiff float64
+	ThermalRating    string            // capped by the usable ceiling
+	Cloudbase        CloudbaseEstimate // the estimate that set the ceiling
+}
+
+// NewHourContext computes the scoring context for an hour at a site.
+func NewHourContext(h *HourlyData, site Site, tc *TuningConfig) *HourContext {
+	gradientDiff, gradientRating := CalcWindGradient(h.WindSpeed, h.PressureLevels, tc)
+	return newHourContext(h, site, tc, gradientDiff, gradientRating)
+}
+
+// newHourContext is NewHourContext with the wind gradient, which does not
+// depend on the launch, already computed.
+func newHourContext(h *HourlyData, site Site, tc *TuningConfig, gradientDiff float64, gradientRating string) *HourContext {
+	cb := CalcCloudbase(h, site, tc)
+	return &HourContext{
+		Data:             h,
+		Site:             site,
+		Tuning:           tc,
+		WindGradient:     gradientRating,
+		WindGradientDiff: gradientDiff,
+		ThermalRating:    CapThermalRating(CalcHourThermalRating(h, tc), cb.ThermalTopFt, tc),
+		Cloudbase:        cb,
+	}
+}
+
+// DefaultScorer is the built-in scoring model, combining the wind, gust,
+// gradient, rain and thermal bonuses and penalties in TuningConfig.Scoring.
+type DefaultScorer struct{}
+
+// Name implements Scorer.
+func (DefaultScorer) Name() string { return ScorerDefault }
+
+// Score implements Scorer using CalcFlyabilityScoreBreakdown.
+func (DefaultScorer) Score(hc *HourContext) ScoreBreakdown {
+	return CalcFlyabilityScoreBreakdown(hc.Data, hc.Site, hc.WindGradient, hc.ThermalRating, hc.Tuning)
+}
+
+// XCPotential implements Scorer using CalcXCPotential.
+func (DefaultScorer) XCPotential(hc *HourContext) string {
+	return CalcXCPotential(hc.Data.CAPE, hc.Cloudbase.ThermalTopFt, hc.Data.WindSpeed, hc.ThermalRating, hc.Tuning)
+}
+
+var (
+	scorersMu sync.RWMutex
+	scorers   = map[string]Scorer{ScorerDefault: DefaultScorer{}}
+)
+
+// RegisterScorer makes a scorer available to ResolveScorer under its name.
+// Names are case-insensitive and must be unique.
+func RegisterScorer(s Scorer) error {
+	if s == nil || s.Name() == "" {
+		return errors.New("scorer must have a name")
+	}
+	key := strings.ToLower(s.Name())
+	scorersMu.Lock()
+	defer scorersMu.Unlock()
+	if _, exists := scorers[key]; exists {
+		return fmt.Errorf("scorer %q already registered", s.Name())
+	}
+	scorers[key] = s
+	return nil
+}
+
+// ResolveScorer looks up a registered scorer by name (case-insensitive).
+// An empty name resolves to the DefaultScorer.
+func ResolveScorer(name string) (Scorer, error) {
+	if name == "" {
+		name = ScorerDefault
+	}
+	scorersMu.RLock()
+	s, ok := scorers[strings.ToLower(name)]
+	scorersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q (valid: %s)", ErrUnknownScorer, name, strings.Join(ScorerNames(), ", "))
+	}
+	return s, nil
+}
+
+// ScorerNames returns the names of all registered scorers, sorted.
+func ScorerNames() []string {
+	scorersMu.RLock()
+	defer scorersMu.RUnlock()
+	names := make([]string, 0, len(scorers))
+	for _, s := range scorers {
+		names = append(names, s.Name())
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,110 @@
+package pgforecast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// flatScorer gives every hour the same score, for testing the Scorer plumbing.
+type flatScorer struct{ score float64 }
+
+func (flatScorer) Name() string { return "flat" }
+
+func (f flatScorer) Score(*HourContext) ScoreBreakdown {
+	return ScoreBreakdown{Base: f.score, Raw: f.score, Exact: f.score, Score: int(f.score)}
+}
+
+func (flatScorer) XCPotential(*HourContext) string { return XCEpic }
+
+func TestResolveScorer(t *testing.T) {
+	for _, name := range []string{"", "default", "DEFAULT"} {
+		s, err := ResolveScorer(name)
+		if err != nil || s.Name() != ScorerDefault {
+			t.Errorf("ResolveScorer(%q) = %v, %v; want the default scorer", name, s, err)
+		}
+	}
+	if _, err := ResolveScorer("nope"); !errors.Is(err, ErrUnknownScorer) {
+		t.Errorf("ResolveScorer(nope) err = %v, want ErrUnknownScorer", err)
+	}
+	if err := RegisterScorer(DefaultScorer{}); err == nil {
+		t.Error("registering a duplicate name should fail")
+	}
+}
+
+func TestDefaultScorerMatchesComputeHourlyMetrics(t *testing.T) {
+	tc := DefaultTuningConfig()
+	site := Site{WindMin: 200, WindMax: 250, Aspect: 225}
+	h := HourlyData{WindSpeed: 12, WindDirection: 225, WindGusts: 16, Temperature: 20, DewPoint: 10, CAPE: 600}
+
+	m := ComputeHourlyMetrics(&h, site, tc)
+	hc := NewHourContext(&h, site, tc)
+	b := DefaultScorer{}.Score(hc)
+	if b.Score != m.FlyabilityScore || b.Exact != m.FlyabilityExact {
+		t.Errorf("DefaultScorer = %d (%.2f), ComputeHourlyMetrics = %d (%.2f)", b.Score, b.Exact, m.FlyabilityScore, m.FlyabilityExact)
+	}
+	if xc := (DefaultScorer{}).XCPotential(hc); xc != m.XCPotential {
+		t.Errorf("XCPotential = %q, want %q", xc, m.XCPotential)
+	}
+}
+
+func TestBuildForecastWithScorer(t *testing.T) {
+	day := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	data := []HourlyData{
+		{Time: day, IsDay: 1, WindSpeed: 40, Temperature: 20, DewPoint: 10},
+		{Time: day.Add(time.Hour), IsDay: 1, WindSpeed: 40, Temperature: 20, DewPoint: 10, WeatherCode: WMOThunderstorm},
+	}
+	opts := ForecastOptions{Timezone: "UTC", Scorer: flatScorer{score: 4}}
+	f, err := buildForecast(context.Background(), Site{Name: "Test"}, opts, fetchResult{data: data})
+	if err != nil {
+		t.Fatalf("buildForecast: %v", err)
+	}
+	hours := f.DetailedDays[0].Hours
+	if hours[0].FlyabilityScore != 4 || hours[0].XCPotential != XCEpic {
+		t.Errorf("hour 0 = %d/%s, want the custom scorer's 4/%s", hours[0].FlyabilityScore, hours[0].XCPotential, XCEpic)
+	}
+	// Storm caps apply whichever scorer is used.
+	if hours[1].FlyabilityScore != ScoreMin {
+		t.Errorf("thunderstorm hour score = %d, want %d", hours[1].FlyabilityScore, ScoreMin)
+	}
+}
+
+// recordingScorer records the contexts it is given.
+type recordingScorer struct {
+	DefaultScorer
+	scored []*HourContext
+	xc     *HourContext
+}
+
+func (r *recordingScorer) Score(hc *HourContext) ScoreBreakdown {
+	r.scored = append(r.scored, hc)
+	return r.DefaultScorer.Score(hc)
+}
+
+func (r *recordingScorer) XCPotential(hc *HourContext) string {
+	r.xc = hc
+	return r.DefaultScorer.XCPotential(hc)
+}
+
+func TestComputeHourlyMetricsReusesHourContext(t *testing.T) {
+	tc := DefaultTuningConfig()
+	site := Site{Name: "Test", Launches: []Launch{
+		{Name: "North", Elevation: 300, WindMin: 330, WindMax: 30, Aspect: 0},
+		{Name: "South", Elevation: 200, WindMin: 160, WindMax: 200, Aspect: 180},
+	}}
+	h := HourlyData{WindSpeed: 12, WindDirection: 180, WindGusts: 16, Temperature: 20, DewPoint: 10, CAPE: 600}
+
+	r := &recordingScorer{}
+	m := ComputeHourlyMetricsWithScorer(&h, site, tc, r, "mph")
+	if len(r.scored) != len(site.Launches) {
+		t.Fatalf("Score called %d times, want once per launch (%d)", len(r.scored), len(site.Launches))
+	}
+	// The best launch's context is reused, not recomputed.
+	if m.Launch != "South" || r.xc != r.scored[1] {
+		t.Errorf("launch = %s, XCPotential context reused = %v; want South's", m.Launch, r.xc == r.scored[1])
+	}
+	if m.CloudbaseAMSLFt != r.scored[1].Cloudbase.CloudbaseAMSLFt {
+		t.Errorf("CloudbaseAMSLFt = %d, want South's %d", m.CloudbaseAMSLFt, r.scored[1].Cloudbase.CloudbaseAMSLFt)
+	}
+}
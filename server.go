@@ -0,0 +1,201 @@
+package pgforecast
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Server exposes forecasts over HTTP as a JSON REST API.
+//
+// Endpoints:
+//
+//	GET /sites                  configured sites
+//	GET /forecast/{site}        forecast for a configured site (name or prefix)
+//	GET /forecast?lat=&lon=     forecast for an ad-hoc location
+//	GET /tuning                 active tuning configuration
+//
+// Forecast endpoints accept units, days, timezone, model, scorer and profile
+// query parameters mirroring ForecastOptions; ad-hoc forecasts also accept
+// name, aspect and wind_range (e.g. 210-260). Forecast responses have the
+// same structure as FormatJSON.
+type Server struct {
+	Sites   []Site
+	Tuning  *TuningConfig
+	Options ForecastOptions // defaults for units, days, timezone, model, cache and HTTP client
+}
+
+// Handler returns the HTTP handler serving the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sites", s.handleSites)
+	mux.HandleFunc("GET /forecast/{site}", s.handleSiteForecast)
+	mux.HandleFunc("GET /forecast", s.handleAdHocForecast)
+	mux.HandleFunc("GET /tuning", s.handleTuning)
+	return mux
+}
+
+func (s *Server) tuning() *TuningConfig {
+	if s.Tuning != nil {
+		return s.Tuning
+	}
+	return DefaultTuningConfig()
+}
+
+func (s *Server) handleSites(w http.ResponseWriter, r *http.Request) {
+	sites := s.Sites
+	if sites == nil {
+		sites = []Site{}
+	}
+	writeJSON(w, http.StatusOK, sites)
+}
+
+func (s *Server) handleTuning(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.tuning())
+}
+
+func (s *Server) handleSiteForecast(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("site")
+	site, ok := FilterSite(s.Sites, name)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("site %q not found", name))
+		return
+	}
+	s.serveForecast(w, r, site)
+}
+
+func (s *Server) handleAdHocForecast(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("lat") == "" || q.Get("lon") == "" {
+		writeError(w, http.StatusBadRequest, "lat and lon are required")
+		return
+	}
+	lat, err := strconv.ParseFloat(q.Get("lat"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid lat %q", q.Get("lat")))
+		return
+	}
+	lon, err := strconv.ParseFloat(q.Get("lon"), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid lon %q", q.Get("lon")))
+		return
+	}
+	site := Site{Name: q.Get("name"), Lat: lat, Lon: lon}
+	if site.Name == "" {
+		site.Name = "Custom"
+	}
+	if v := q.Get("aspect"); v != "" {
+		if site.Aspect, err = strconv.Atoi(v); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid aspect %q", v))
+			return
+		}
+		site.BestDir = site.Aspect
+	}
+	if v := q.Get("wind_range"); v != "" {
+		parts := strings.Split(v, "-")
+		var errMin, errMax error
+		if len(parts) == 2 {
+			site.WindMin, errMin = strconv.Atoi(parts[0])
+			site.WindMax, errMax = strconv.Atoi(parts[1])
+		}
+		if len(parts) != 2 || errMin != nil || errMax != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid wind_range %q", v))
+			return
+		}
+	}
+	s.serveForecast(w, r, site)
+}
+
+func (s *Server) serveForecast(w http.ResponseWriter, r *http.Request, site Site) {
+	opts, err := s.requestOptions(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// A bad config is the server's fault, not the request's or Open-Meteo's.
+	if _, err := ProfileTuning(site, opts.Tuning, opts.Profile); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	f, err := GenerateForecastWithContext(r.Context(), site, opts)
+	if err != nil {
+		if r.Context().Err() != nil {
+			// Client went away; nobody to answer.
+			return
+		}
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	FormatJSON(w, f, opts.Tuning)
+}
+
+// requestOptions merges the request's query parameters over the server's
+// default ForecastOptions.
+func (s *Server) requestOptions(r *http.Request) (ForecastOptions, error) {
+	opts := s.Options
+	opts.Tuning = s.tuning()
+	opts.OutputFormat = "json"
+	q := r.URL.Query()
+
+	if v := q.Get("units"); v != "" {
+		switch v {
+		case "mph", "kph", "kmh", "knots", "kn", "ms":
+			opts.Units = v
+		default:
+			return opts, fmt.Errorf("invalid units %q (valid: mph, kph, knots, ms)", v)
+		}
+	}
+	if opts.Units == "" {
+		opts.Units = "mph"
+	}
+	if v := q.Get("days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 || days > 16 {
+			return opts, fmt.Errorf("invalid days %q (1-16)", v)
+		}
+		opts.DetailedDays = days
+	}
+	if v := q.Get("timezone"); v != "" {
+		if _, err := time.LoadLocation(v); err != nil {
+			return opts, fmt.Errorf("invalid timezone %q", v)
+		}
+		opts.Timezone = v
+	}
+	if v := q.Get("model"); v != "" {
+		opts.Model = v
+	}
+	if _, err := ResolveModel(opts.Model); err != nil {
+		return opts, err
+	}
+	if v := q.Get("scorer"); v != "" {
+		scorer, err := ResolveScorer(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.Scorer = scorer
+	}
+	if v := q.Get("profile"); v != "" {
+		p, err := ResolveProfile(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.Profile = p.Name
+	}
+	return opts, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,111 @@
+package pgforecast
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func testServer(t *testing.T) *Server {
+	t.Helper()
+	client := mockClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"hourly":{"time":["2026-02-19T12:00"],"is_day":[1],"wind_speed_10m":[12.0]}}`))),
+			Header:     make(http.Header),
+		}, nil
+	})
+	return &Server{
+		Sites:   []Site{{Name: "Ringstead", Lat: 50.64, Lon: -2.34}, {Name: "Bell Hill", Lat: 50.87, Lon: -2.29}},
+		Options: ForecastOptions{HTTPClient: client, Timezone: "UTC"},
+	}
+}
+
+func TestServerEndpoints(t *testing.T) {
+	h := testServer(t).Handler()
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/sites", http.StatusOK},
+		{"/tuning", http.StatusOK},
+		{"/forecast/Ringstead", http.StatusOK},
+		{"/forecast/" + url.PathEscape("bell hill") + "?units=kph&days=2", http.StatusOK},
+		{"/forecast/Nowhere", http.StatusNotFound},
+		{"/forecast/Ringstead?units=furlongs", http.StatusBadRequest},
+		{"/forecast/Ringstead?model=nam", http.StatusBadRequest},
+		{"/forecast/Ringstead?scorer=nope", http.StatusBadRequest},
+		{"/forecast/Ringstead?profile=student", http.StatusOK},
+		{"/forecast/Ringstead?profile=nope", http.StatusBadRequest},
+		{"/forecast/Ringstead?timezone=Mars/Olympus", http.StatusBadRequest},
+		{"/forecast?lat=50.6&lon=-2.3&aspect=225&wind_range=210-260", http.StatusOK},
+		{"/forecast?lat=95&lon=-2.3", http.StatusBadRequest},
+		{"/forecast", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d; body: %s", rec.Code, tt.status, rec.Body)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+		})
+	}
+}
+
+func TestServerForecastBody(t *testing.T) {
+	h := testServer(t).Handler()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/forecast/ring?units=knots", nil))
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding body: %v", err)
+	}
+	if body["units"] != "knots" {
+		t.Errorf("units = %v, want knots", body["units"])
+	}
+	if _, ok := body["display"]; !ok {
+		t.Error("response missing display config (FormatJSON structure)")
+	}
+	site, _ := body["site"].(map[string]interface{})
+	if site["name"] != "Ringstead" {
+		t.Errorf("site.name = %v, want Ringstead", site["name"])
+	}
+}
+
+func TestServerSiteDisplayOverride(t *testing.T) {
+	s := testServer(t)
+	s.Sites[0].Tuning = TuningOverrides{"display": map[string]any{"gradient": map[string]any{"high": map[string]any{"icon": "🚫"}}}}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/forecast/Ringstead", nil))
+
+	var body struct {
+		Display DisplayConfig `json:"display"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding body: %v", err)
+	}
+	if got := body.Display.Gradient.High.Icon; got != "🚫" {
+		t.Errorf("display.gradient.high.icon = %q, want the site's override", got)
+	}
+}
+
+func TestServerInvalidTuning(t *testing.T) {
+	s := testServer(t)
+	s.Tuning = DefaultTuningConfig()
+	s.Tuning.Wind.IdealMax = 2
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/forecast/Ringstead", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500; body: %s", rec.Code, rec.Body)
+	}
+}
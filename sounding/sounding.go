@@ -0,0 +1,173 @@
+// Package sounding renders the pressure-level profile of a forecast hour as
+// an SVG skew-T or emagram: environmental temperature and dewpoint, dry and
+// moist adiabats, the path of a surface parcel, and wind barbs.
+package sounding
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/matt-FFFFFF/pgforecast"
+)
+
+// ErrNoProfile is returned when an hour has too few pressure levels above the
+// site to draw a sounding.
+var ErrNoProfile = errors.New("not enough pressure levels for a sounding")
+
+// kappa is R/cp for dry air, the exponent of the dry adiabat.
+const kappa = pgforecast.GasConstantDryAir / pgforecast.SpecificHeatDryAir
+
+// parcelStepHPa is the pressure step used when lifting the parcel.
+const parcelStepHPa = 5.0
+
+// Level is one point of the environmental profile.
+type Level struct {
+	Pressure      float64 `json:"pressure_hpa"`
+	HeightM       float64 `json:"height_m"` // AMSL
+	Temperature   float64 `json:"temperature"`
+	DewPoint      float64 `json:"dew_point"`
+	HasDewPoint   bool    `json:"has_dew_point"`
+	WindSpeed     float64 `json:"wind_speed"` // in the forecast's wind units
+	WindDirection float64 `json:"wind_direction"`
+}
+
+// Point is a temperature (°C) at a pressure (hPa).
+type Point struct {
+	Pressure    float64 `json:"pressure_hpa"`
+	Temperature float64 `json:"temperature"`
+}
+
+// Sounding is the profile above a site for one hour, ready for plotting.
+type Sounding struct {
+	Site   pgforecast.Site `json:"site"`
+	Time   time.Time       `json:"time"`
+	Levels []Level         `json:"levels"` // surface first, decreasing pressure
+
+	// Parcel is the path of a surface parcel lifted dry-adiabatically to the
+	// cloudbase and moist-adiabatically above it.
+	Parcel []Point `json:"parcel"`
+
+	Cloudbase pgforecast.CloudbaseEstimate `json:"cloudbase"`
+	// CloudbasePressure and ThermalTopPressure are the pressures (hPa) of
+	// the cloudbase and the dry thermal top.
+	CloudbasePressure  float64 `json:"cloudbase_pressure_hpa"`
+	ThermalTopPressure float64 `json:"thermal_top_pressure_hpa"`
+
+	heights []Level // levels with heights, increasing height, for interpolation
+}
+
+// New builds the sounding for h above site. Levels below the site elevation
+// are dropped and the surface observations become the lowest level. The
+// cloudbase and thermal top are the same estimates used by the forecast
+// (see pgforecast.CalcCloudbase).
+func New(h *pgforecast.HourlyData, site pgforecast.Site, tc *pgforecast.TuningConfig) (*Sounding, error) {
+	if tc == nil {
+		tc = pgforecast.DefaultTuningConfig()
+	}
+	s := &Sounding{Site: site, Time: h.Time}
+
+	for _, l := range h.PressureLevels {
+		if l.GeopotentialHeight <= 0 || l.Pressure <= 0 {
+			continue
+		}
+		s.heights = append(s.heights, Level{
+			Pressure:      float64(l.Pressure),
+			HeightM:       l.GeopotentialHeight,
+			Temperature:   l.Temperature,
+			DewPoint:      l.DewPoint,
+			HasDewPoint:   l.RelativeHumidity > 0,
+			WindSpeed:     l.WindSpeed,
+			WindDirection: l.WindDirection,
+		})
+	}
+	if len(s.heights) < 2 {
+		return nil, ErrNoProfile
+	}
+	sort.Slice(s.heights, func(i, j int) bool { return s.heights[i].HeightM < s.heights[j].HeightM })
+
+	elev := float64(site.Elevation)
+	s.Levels = append(s.Levels, Level{
+		Pressure:      s.PressureAt(elev),
+		HeightM:       elev,
+		Temperature:   h.Temperature,
+		DewPoint:      h.DewPoint,
+		HasDewPoint:   true,
+		WindSpeed:     h.WindSpeed,
+		WindDirection: h.WindDirection,
+	})
+	for _, l := range s.heights {
+		if l.HeightM > elev {
+			s.Levels = append(s.Levels, l)
+		}
+	}
+	if len(s.Levels) < 2 {
+		return nil, ErrNoProfile
+	}
+
+	s.Cloudbase = pgforecast.CalcCloudbase(h, site, tc)
+	s.CloudbasePressure = s.PressureAt(float64(s.Cloudbase.CloudbaseAMSLFt) / pgforecast.MetersToFeet)
+	s.ThermalTopPressure = s.PressureAt(float64(s.Cloudbase.ThermalTopAMSLFt) / pgforecast.MetersToFeet)
+	s.Parcel = liftParcel(s.Levels[0], s.CloudbasePressure, s.Levels[len(s.Levels)-1].Pressure)
+	return s, nil
+}
+
+// PressureAt interpolates the pressure (hPa) at a height AMSL (m), linear in
+// log-pressure and extrapolated beyond the highest and lowest levels.
+func (s *Sounding) PressureAt(z float64) float64 {
+	ref := s.heights
+	i := sort.Search(len(ref), func(i int) bool { return ref[i].HeightM >= z })
+	switch {
+	case i == 0:
+		i = 1
+	case i == len(ref):
+		i = len(ref) - 1
+	}
+	a, b := ref[i-1], ref[i]
+	f := (z - a.HeightM) / (b.HeightM - a.HeightM)
+	return math.Exp(math.Log(a.Pressure) + f*(math.Log(b.Pressure)-math.Log(a.Pressure)))
+}
+
+// liftParcel lifts the surface parcel to topP, following the dry adiabat to
+// lclP and the moist adiabat above it.
+func liftParcel(surface Level, lclP, topP float64) []Point {
+	theta := (surface.Temperature + pgforecast.KelvinOffset) / math.Pow(surface.Pressure, kappa)
+	path := []Point{{surface.Pressure, surface.Temperature}}
+	p := surface.Pressure
+	for p > lclP && p > topP {
+		p = math.Max(math.Max(p-parcelStepHPa, lclP), topP)
+		path = append(path, Point{p, theta*math.Pow(p, kappa) - pgforecast.KelvinOffset})
+	}
+	return append(path, moistAdiabat(path[len(path)-1], topP)[1:]...)
+}
+
+// dryAdiabat returns the temperature (°C) at p of the dry adiabat through
+// thetaC (°C at 1000 hPa).
+func dryAdiabat(thetaC, p float64) float64 {
+	return (thetaC+pgforecast.KelvinOffset)*math.Pow(p/1000, kappa) - pgforecast.KelvinOffset
+}
+
+// moistAdiabat integrates the saturated adiabat from start to endP, in either
+// direction.
+func moistAdiabat(start Point, endP float64) []Point {
+	path := []Point{start}
+	t, p := start.Temperature, start.Pressure
+	step := -parcelStepHPa
+	if endP > p {
+		step = parcelStepHPa
+	}
+	for (step < 0 && p > endP) || (step > 0 && p < endP) {
+		next := p + step
+		if (step < 0 && next < endP) || (step > 0 && next > endP) {
+			next = endP
+		}
+		// dT = Γm · dz with dz = -(Rd·T/g)·dln(p).
+		tk := t + pgforecast.KelvinOffset
+		dz := -pgforecast.GasConstantDryAir * tk / pgforecast.Gravity * math.Log(next/p)
+		t -= pgforecast.MoistAdiabaticLapseRate(t, p) * dz / pgforecast.MetersPerKm
+		p = next
+		path = append(path, Point{p, t})
+	}
+	return path
+}
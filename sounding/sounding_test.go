@@ -0,0 +1,175 @@
+package sounding
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matt-FFFFFF/pgforecast"
+)
+
+func testHour() *pgforecast.HourlyData {
+	return &pgforecast.HourlyData{
+		Time:          time.Date(2026, 6, 1, 13, 0, 0, 0, time.UTC),
+		Temperature:   20,
+		DewPoint:      10,
+		WindSpeed:     10,
+		WindDirection: 240,
+		PressureLevels: []pgforecast.PressureLevel{
+			{Pressure: 1000, GeopotentialHeight: 110, Temperature: 21, DewPoint: 10, RelativeHumidity: 50, WindSpeed: 8, WindDirection: 230},
+			{Pressure: 950, GeopotentialHeight: 540, Temperature: 17, DewPoint: 8, RelativeHumidity: 55, WindSpeed: 12, WindDirection: 240},
+			{Pressure: 900, GeopotentialHeight: 990, Temperature: 13, DewPoint: 5, RelativeHumidity: 58, WindSpeed: 15, WindDirection: 250},
+			{Pressure: 850, GeopotentialHeight: 1460, Temperature: 9, DewPoint: 2, RelativeHumidity: 60, WindSpeed: 20, WindDirection: 255},
+			{Pressure: 700, GeopotentialHeight: 3010, Temperature: -2, DewPoint: -10, RelativeHumidity: 50, WindSpeed: 35, WindDirection: 265},
+			{Pressure: 600, GeopotentialHeight: 4200, Temperature: -10, WindSpeed: 60, WindDirection: 270},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	site := pgforecast.Site{Name: "Test", Elevation: 300}
+	s, err := New(testHour(), site, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// 1000 hPa is below launch and dropped; the surface becomes the first level.
+	if len(s.Levels) != 6 {
+		t.Fatalf("levels = %d, want 6", len(s.Levels))
+	}
+	sfc := s.Levels[0]
+	if sfc.HeightM != 300 || sfc.Temperature != 20 || sfc.Pressure >= 1000 || sfc.Pressure <= 950 {
+		t.Errorf("surface = %+v, want 300m, 20°C, between 950 and 1000 hPa", sfc)
+	}
+	if s.Levels[len(s.Levels)-1].HasDewPoint {
+		t.Error("600 hPa has no humidity, so its dewpoint should not be plotted")
+	}
+
+	// The parcel runs from the surface to the top of the profile, dry below
+	// the cloudbase and cooling more slowly above it.
+	first, last := s.Parcel[0], s.Parcel[len(s.Parcel)-1]
+	if first.Pressure != sfc.Pressure || first.Temperature != sfc.Temperature {
+		t.Errorf("parcel starts at %+v, want surface", first)
+	}
+	if last.Pressure != 600 {
+		t.Errorf("parcel ends at %.0f hPa, want 600", last.Pressure)
+	}
+	if s.CloudbasePressure >= sfc.Pressure || s.CloudbasePressure <= 600 {
+		t.Errorf("cloudbase pressure = %.0f hPa, want within the profile", s.CloudbasePressure)
+	}
+	for _, pt := range s.Parcel {
+		if pt.Pressure < s.CloudbasePressure {
+			break
+		}
+		theta := (sfc.Temperature + pgforecast.KelvinOffset) / math.Pow(sfc.Pressure, kappa)
+		want := theta*math.Pow(pt.Pressure, kappa) - pgforecast.KelvinOffset
+		if math.Abs(pt.Temperature-want) > 0.01 {
+			t.Fatalf("parcel at %.0f hPa = %.2f°C, want dry adiabat %.2f°C", pt.Pressure, pt.Temperature, want)
+		}
+	}
+	theta := (sfc.Temperature+pgforecast.KelvinOffset)*math.Pow(1000/sfc.Pressure, kappa) - pgforecast.KelvinOffset
+	if dry := dryAdiabat(theta, 600); last.Temperature <= dry {
+		t.Errorf("parcel at 600 hPa = %.1f°C, want warmer than the surface dry adiabat (%.1f°C)", last.Temperature, dry)
+	}
+}
+
+func TestNew_NoProfile(t *testing.T) {
+	h := testHour()
+	h.PressureLevels = h.PressureLevels[:1]
+	if _, err := New(h, pgforecast.Site{}, nil); !errors.Is(err, ErrNoProfile) {
+		t.Errorf("err = %v, want ErrNoProfile", err)
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	for _, emagram := range []bool{false, true} {
+		var buf bytes.Buffer
+		site := pgforecast.Site{Name: "Bo Peep & Firle", Elevation: 200}
+		if err := Render(&buf, testHour(), site, nil, Options{Emagram: emagram}); err != nil {
+			t.Fatalf("Render: %v", err)
+		}
+		out := buf.String()
+
+		// Must be well-formed XML.
+		dec := xml.NewDecoder(strings.NewReader(out))
+		for {
+			_, err := dec.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("emagram=%v: invalid SVG: %v", emagram, err)
+			}
+		}
+
+		for _, want := range []string{"Bo Peep &amp; Firle", colourTemperature, colourDewPoint, colourParcel, "Cloudbase ~", "Wind (kt)"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("emagram=%v: SVG missing %q", emagram, want)
+			}
+		}
+		wantKind := "Skew-T"
+		if emagram {
+			wantKind = "Emagram"
+		}
+		if !strings.Contains(out, wantKind) {
+			t.Errorf("SVG title missing %q", wantKind)
+		}
+	}
+}
+
+func TestWriteSVGTitleTime(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	site := pgforecast.Site{Name: "Test", Elevation: 300}
+	tests := []struct {
+		loc  *time.Location
+		want string
+	}{
+		{nil, "Mon 1 Jun 13:00 UTC"},
+		{london, "Mon 1 Jun 14:00 BST"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Render(&buf, testHour(), site, nil, Options{Location: tt.loc}); err != nil {
+			t.Fatalf("Render: %v", err)
+		}
+		if !strings.Contains(buf.String(), tt.want) {
+			t.Errorf("loc=%v: SVG title missing %q", tt.loc, tt.want)
+		}
+	}
+}
+
+func TestWriteBarb(t *testing.T) {
+	tests := []struct {
+		knots                   float64
+		flags, barbs, halfBarbs int
+	}{
+		{0, 0, 0, 0},
+		{5, 0, 0, 1},
+		{25, 0, 2, 1},
+		{65, 1, 1, 1},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		writeBarb(&buf, 0, 0, tt.knots, 270)
+		out := buf.String()
+		if tt.knots < 2.5 {
+			if !strings.Contains(out, "<circle") {
+				t.Errorf("%.0fkt: want calm circle, got %s", tt.knots, out)
+			}
+			continue
+		}
+		flags := strings.Count(out, "<path")
+		lines := strings.Count(out, "<line") - 1 // minus staff
+		if flags != tt.flags || lines != tt.barbs+tt.halfBarbs {
+			t.Errorf("%.0fkt: flags=%d barbs=%d, want %d and %d", tt.knots, flags, lines, tt.flags, tt.barbs+tt.halfBarbs)
+		}
+	}
+}
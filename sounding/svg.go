@@ -0,0 +1,348 @@
+package sounding
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/matt-FFFFFF/pgforecast"
+)
+
+// Options control how a sounding is drawn.
+type Options struct {
+	Width  int // SVG width in px (default 640)
+	Height int // SVG height in px (default 720)
+
+	// Emagram draws vertical isotherms instead of skewing them.
+	Emagram bool
+
+	// BottomPressure and TopPressure bound the diagram (hPa, default 1050
+	// and 500).
+	BottomPressure float64
+	TopPressure    float64
+
+	// Units is the wind speed unit of the forecast data (mph, kph, knots,
+	// ms); barbs are always drawn in knots.
+	Units string
+
+	// Location is the time zone of the title's time (default UTC).
+	Location *time.Location
+}
+
+// Diagram geometry.
+const (
+	marginLeft   = 56
+	marginRight  = 72
+	marginTop    = 40
+	marginBottom = 56
+
+	skewTMinC = -20.0 // temperature at the bottom-left corner of a skew-T
+	skewTMaxC = 40.0
+	skewC     = 35.0 // isotherm shift (°C) from bottom to top of a skew-T
+
+	emagramMinC = -35.0
+	emagramMaxC = 35.0
+
+	barbLength = 28.0
+)
+
+// Plot colours.
+const (
+	colourTemperature = "#d62728"
+	colourDewPoint    = "#2ca02c"
+	colourParcel      = "#ff7f0e"
+	colourDry         = "#c9a26b"
+	colourMoist       = "#7fb3d5"
+	colourGrid        = "#bbbbbb"
+	colourCloudbase   = "#1f77b4"
+	colourThermalTop  = "#9467bd"
+)
+
+func (o Options) withDefaults() Options {
+	if o.Width <= 0 {
+		o.Width = 640
+	}
+	if o.Height <= 0 {
+		o.Height = 720
+	}
+	if o.BottomPressure <= 0 {
+		o.BottomPressure = 1050
+	}
+	if o.TopPressure <= 0 || o.TopPressure >= o.BottomPressure {
+		o.TopPressure = 500
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	return o
+}
+
+// diagram maps temperature and pressure to SVG coordinates.
+type diagram struct {
+	opts         Options
+	left, top    float64
+	width, high  float64
+	minC, maxC   float64
+	skew         float64
+	logBottomTop float64
+}
+
+func newDiagram(o Options) diagram {
+	d := diagram{
+		opts:         o,
+		left:         marginLeft,
+		top:          marginTop,
+		width:        float64(o.Width - marginLeft - marginRight),
+		high:         float64(o.Height - marginTop - marginBottom),
+		minC:         skewTMinC,
+		maxC:         skewTMaxC,
+		skew:         skewC,
+		logBottomTop: math.Log(o.BottomPressure / o.TopPressure),
+	}
+	if o.Emagram {
+		d.minC, d.maxC, d.skew = emagramMinC, emagramMaxC, 0
+	}
+	return d
+}
+
+// frac is the fraction of the diagram height at pressure p, 0 at the bottom.
+func (d diagram) frac(p float64) float64 {
+	return math.Log(d.opts.BottomPressure/p) / d.logBottomTop
+}
+
+func (d diagram) y(p float64) float64 {
+	return d.top + d.high*(1-d.frac(p))
+}
+
+func (d diagram) x(t, p float64) float64 {
+	return d.left + (t-d.minC+d.skew*d.frac(p))/(d.maxC-d.minC)*d.width
+}
+
+// WriteSVG draws the sounding as a standalone SVG document.
+func (s *Sounding) WriteSVG(w io.Writer, opts Options) error {
+	o := opts.withDefaults()
+	d := newDiagram(o)
+	b := bufio.NewWriter(w)
+
+	kind := "Skew-T"
+	if o.Emagram {
+		kind = "Emagram"
+	}
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`+"\n",
+		o.Width, o.Height, o.Width, o.Height)
+	fmt.Fprintf(b, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+	fmt.Fprintf(b, `<text x="%d" y="22" font-size="14" font-weight="bold">%s — %s (%s)</text>`+"\n",
+		marginLeft, html.EscapeString(s.Site.Name), s.Time.In(o.Location).Format("Mon 2 Jan 15:04 MST"), kind)
+	fmt.Fprintf(b, `<clipPath id="plot"><rect x="%.1f" y="%.1f" width="%.1f" height="%.1f"/></clipPath>`+"\n",
+		d.left, d.top, d.width, d.high)
+
+	b.WriteString(`<g clip-path="url(#plot)" fill="none">` + "\n")
+	d.writeGrid(b)
+	d.writeAdiabats(b)
+	d.writeLevelLine(b, s.CloudbasePressure, colourCloudbase, fmt.Sprintf("Cloudbase ~%dft AMSL", s.Cloudbase.CloudbaseAMSLFt))
+	if s.ThermalTopPressure > s.CloudbasePressure+1 {
+		d.writeLevelLine(b, s.ThermalTopPressure, colourThermalTop, fmt.Sprintf("Thermal top ~%dft AMSL", s.Cloudbase.ThermalTopAMSLFt))
+	}
+	d.writePath(b, s.Parcel, colourParcel, 2, "6 4")
+
+	temps := make([]Point, len(s.Levels))
+	var dews []Point
+	for i, l := range s.Levels {
+		temps[i] = Point{l.Pressure, l.Temperature}
+		if l.HasDewPoint {
+			dews = append(dews, Point{l.Pressure, l.DewPoint})
+		}
+	}
+	d.writePath(b, temps, colourTemperature, 2.5, "")
+	d.writePath(b, dews, colourDewPoint, 2.5, "")
+	b.WriteString("</g>\n")
+
+	fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="none" stroke="#333333"/>`+"\n",
+		d.left, d.top, d.width, d.high)
+	d.writeAxes(b)
+	d.writeBarbs(b, s.Levels)
+	d.writeLegend(b)
+	b.WriteString("</svg>\n")
+	return b.Flush()
+}
+
+// writeGrid draws isobars and isotherms.
+func (d diagram) writeGrid(w io.Writer) {
+	for p := 1000.0; p >= d.opts.TopPressure; p -= 50 {
+		if p > d.opts.BottomPressure {
+			continue
+		}
+		fmt.Fprintf(w, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="0.5"/>`+"\n",
+			d.left, d.y(p), d.left+d.width, d.y(p), colourGrid)
+	}
+	for t := d.minC - d.skew - 10; t <= d.maxC; t += 10 {
+		fmt.Fprintf(w, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="0.5"/>`+"\n",
+			d.x(t, d.opts.BottomPressure), d.y(d.opts.BottomPressure), d.x(t, d.opts.TopPressure), d.y(d.opts.TopPressure), colourGrid)
+	}
+}
+
+// writeAdiabats draws dry adiabats every 10°C and saturated adiabats every
+// 5°C, labelled by their temperature at 1000 hPa.
+func (d diagram) writeAdiabats(w io.Writer) {
+	for theta := -20.0; theta <= 80; theta += 10 {
+		var pts []Point
+		for p := d.opts.BottomPressure; p >= d.opts.TopPressure; p -= 10 {
+			pts = append(pts, Point{p, dryAdiabat(theta, p)})
+		}
+		d.writePath(w, pts, colourDry, 0.8, "")
+	}
+	for t := -10.0; t <= 35; t += 5 {
+		start := Point{1000, t}
+		down := moistAdiabat(start, d.opts.BottomPressure)
+		up := moistAdiabat(start, d.opts.TopPressure)
+		pts := make([]Point, 0, len(down)+len(up))
+		for i := len(down) - 1; i > 0; i-- {
+			pts = append(pts, down[i])
+		}
+		d.writePath(w, append(pts, up...), colourMoist, 0.8, "4 3")
+	}
+}
+
+// writePath draws a polyline through pts.
+func (d diagram) writePath(w io.Writer, pts []Point, colour string, width float64, dash string) {
+	if len(pts) < 2 {
+		return
+	}
+	var sb strings.Builder
+	for i, pt := range pts {
+		cmd := "L"
+		if i == 0 {
+			cmd = "M"
+		}
+		fmt.Fprintf(&sb, "%s%.1f,%.1f", cmd, d.x(pt.Temperature, pt.Pressure), d.y(pt.Pressure))
+	}
+	dashAttr := ""
+	if dash != "" {
+		dashAttr = fmt.Sprintf(` stroke-dasharray="%s"`, dash)
+	}
+	fmt.Fprintf(w, `<path d="%s" stroke="%s" stroke-width="%.1f"%s/>`+"\n", sb.String(), colour, width, dashAttr)
+}
+
+// writeLevelLine draws a labelled horizontal line at pressure p.
+func (d diagram) writeLevelLine(w io.Writer, p float64, colour, label string) {
+	if p <= d.opts.TopPressure || p >= d.opts.BottomPressure {
+		return
+	}
+	y := d.y(p)
+	fmt.Fprintf(w, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="1.2" stroke-dasharray="2 3"/>`+"\n",
+		d.left, y, d.left+d.width, y, colour)
+	fmt.Fprintf(w, `<text x="%.1f" y="%.1f" fill="%s" stroke="none">%s</text>`+"\n", d.left+6, y-4, colour, html.EscapeString(label))
+}
+
+// writeAxes labels isobars on the left and isotherms along the bottom.
+func (d diagram) writeAxes(w io.Writer) {
+	for p := 1000.0; p >= d.opts.TopPressure; p -= 100 {
+		if p > d.opts.BottomPressure {
+			continue
+		}
+		fmt.Fprintf(w, `<text x="%.1f" y="%.1f" text-anchor="end">%.0f</text>`+"\n", d.left-6, d.y(p)+4, p)
+	}
+	fmt.Fprintf(w, `<text x="14" y="%.1f" transform="rotate(-90 14 %.1f)" text-anchor="middle">Pressure (hPa)</text>`+"\n",
+		d.top+d.high/2, d.top+d.high/2)
+
+	bottom := d.opts.BottomPressure
+	for t := d.minC; t <= d.maxC; t += 10 {
+		fmt.Fprintf(w, `<text x="%.1f" y="%.1f" text-anchor="middle">%.0f</text>`+"\n", d.x(t, bottom), d.top+d.high+16, t)
+	}
+	fmt.Fprintf(w, `<text x="%.1f" y="%.1f" text-anchor="middle">Temperature (°C)</text>`+"\n", d.left+d.width/2, d.top+d.high+32)
+}
+
+// writeLegend draws the key below the temperature axis.
+func (d diagram) writeLegend(w io.Writer) {
+	items := []struct {
+		colour, dash, label string
+	}{
+		{colourTemperature, "", "Temperature"},
+		{colourDewPoint, "", "Dew point"},
+		{colourParcel, "6 4", "Parcel"},
+		{colourDry, "", "Dry adiabat"},
+		{colourMoist, "4 3", "Moist adiabat"},
+	}
+	x := d.left
+	y := float64(d.opts.Height) - 8
+	for _, it := range items {
+		dash := ""
+		if it.dash != "" {
+			dash = fmt.Sprintf(` stroke-dasharray="%s"`, it.dash)
+		}
+		fmt.Fprintf(w, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="2"%s/>`+"\n", x, y-4, x+18, y-4, it.colour, dash)
+		fmt.Fprintf(w, `<text x="%.1f" y="%.1f">%s</text>`+"\n", x+22, y, it.label)
+		x += 26 + float64(len(it.label))*6.5
+	}
+}
+
+// writeBarbs draws a wind barb for each level in the right margin.
+func (d diagram) writeBarbs(w io.Writer, levels []Level) {
+	x := d.left + d.width + float64(marginRight)/2
+	fmt.Fprintf(w, `<text x="%.1f" y="%.1f" text-anchor="middle">Wind (kt)</text>`+"\n", x, d.top-6)
+	for _, l := range levels {
+		if l.Pressure < d.opts.TopPressure || l.Pressure > d.opts.BottomPressure {
+			continue
+		}
+		writeBarb(w, x, d.y(l.Pressure), l.WindSpeed*knotsPer(d.opts.Units), l.WindDirection)
+	}
+}
+
+// writeBarb draws a wind barb at (x, y) whose staff points into the wind.
+// The barb is drawn pointing north and rotated to the wind direction.
+func writeBarb(w io.Writer, x, y, knots, dir float64) {
+	if knots < 2.5 {
+		fmt.Fprintf(w, `<circle cx="%.1f" cy="%.1f" r="4" fill="none" stroke="#000000"/>`+"\n", x, y)
+		return
+	}
+	fmt.Fprintf(w, `<g transform="rotate(%.0f %.1f %.1f)" stroke="#000000" stroke-width="1.2" fill="#000000">`+"\n", dir, x, y)
+	fmt.Fprintf(w, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f"/>`+"\n", x, y, x, y-barbLength)
+
+	rest := math.Round(knots/5) * 5
+	pos := y - barbLength
+	for rest >= 50 {
+		fmt.Fprintf(w, `<path d="M%.1f,%.1f L%.1f,%.1f L%.1f,%.1f Z"/>`+"\n", x, pos, x+10, pos+2, x, pos+6)
+		pos += 8
+		rest -= 50
+	}
+	for rest >= 10 {
+		fmt.Fprintf(w, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f"/>`+"\n", x, pos, x+10, pos-4)
+		pos += 4
+		rest -= 10
+	}
+	if rest >= 5 {
+		if pos == y-barbLength {
+			pos += 4 // a lone half barb sits just below the tip
+		}
+		fmt.Fprintf(w, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f"/>`+"\n", x, pos, x+5, pos-2)
+	}
+	fmt.Fprintln(w, `</g>`)
+}
+
+// knotsPer returns the number of knots in one unit of the given wind speed
+// unit.
+func knotsPer(units string) float64 {
+	switch units {
+	case "kph", "kmh":
+		return 0.539957
+	case "knots", "kn":
+		return 1
+	case "ms":
+		return 1.943844
+	default:
+		return 0.868976
+	}
+}
+
+// Render builds the sounding for h above site and writes it as SVG.
+func Render(w io.Writer, h *pgforecast.HourlyData, site pgforecast.Site, tc *pgforecast.TuningConfig, opts Options) error {
+	s, err := New(h, site, tc)
+	if err != nil {
+		return err
+	}
+	return s.WriteSVG(w, opts)
+}
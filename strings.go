@@ -62,8 +62,110 @@ const (
 	XCLow = "Low"
 )
 
+// Storm risk levels.
+const (
+	// StormNone indicates no risk of overdevelopment or thunderstorms.
+	StormNone = "None"
+	// StormLow indicates unstable air where isolated showers may develop.
+	StormLow = "Low"
+	// StormModerate indicates overdevelopment is likely; flying is not advised.
+	StormModerate = "Moderate"
+	// StormHigh indicates thunderstorms are expected or forecast.
+	StormHigh = "High"
+)
+
+// Warning severities.
+const (
+	// SeverityCaution marks a hazard an experienced pilot may manage.
+	SeverityCaution = "Caution"
+	// SeverityDanger marks a hazard that makes flying unsafe.
+	SeverityDanger = "Danger"
+)
+
+// Warning messages, formatted with the values that triggered them.
+const (
+	// WarnWindMsg describes a strong surface wind.
+	WarnWindMsg = "Strong wind: %.0f%s"
+	// WarnGustsMsg describes gusts and their gust factor.
+	WarnGustsMsg = "Gusts to %.0f%s (%.1f× the mean wind)"
+	// WarnWindAloftMsg describes the strongest wind aloft, its pressure level and height.
+	WarnWindAloftMsg = "Wind aloft: %.0f%s at %dhPa (~%.0fm)"
+	// WarnGradientMsg describes the wind gradient between the surface and 850hPa.
+	WarnGradientMsg = "%s wind gradient (+%.0f by 850hPa)"
+	// WarnDirectionMsg describes wind outside the site's direction range.
+	WarnDirectionMsg = "Wind %s, %.0f° off the site's range"
+	// WarnRotorMsg describes wind blowing over the back of the hill.
+	WarnRotorMsg = "Wind over the back — rotor risk on launch"
+	// WarnRainMsg describes forecast rain.
+	WarnRainMsg = "Rain: %.1fmm"
+	// WarnRainProbMsg describes a high chance of rain.
+	WarnRainProbMsg = "Rain likely: %.0f%%"
+	// WarnFogMsg describes fog or cloud on the hill.
+	WarnFogMsg = "Fog or cloud on the hill"
+	// WarnLowCloudbaseMsg describes a low cloudbase above launch.
+	WarnLowCloudbaseMsg = "Low cloudbase: %dft above launch"
+	// WarnVisibilityMsg describes poor visibility.
+	WarnVisibilityMsg = "Poor visibility: %.1fkm"
+	// WarnStormMsg describes the storm risk.
+	WarnStormMsg = "%s storm risk — overdevelopment likely"
+)
+
+// Flyability score breakdown reasons.
+const (
+	// ReasonWindIdeal explains the ideal wind speed bonus.
+	ReasonWindIdeal = "Wind speed ideal"
+	// ReasonWindAcceptable explains the acceptable wind speed bonus.
+	ReasonWindAcceptable = "Wind speed acceptable"
+	// ReasonWindHigh explains the penalty for wind above the acceptable range.
+	ReasonWindHigh = "Wind too strong"
+	// ReasonWindDangerous explains the penalty for dangerously strong wind.
+	ReasonWindDangerous = "Wind dangerously strong"
+	// ReasonDirOn explains the bonus for wind within the site's direction range.
+	ReasonDirOn = "Wind on the hill"
+	// ReasonDirFarOff explains the penalty for wind far outside the site's range.
+	ReasonDirFarOff = "Wind far off direction"
+	// ReasonDirModerateOff explains the penalty for wind well outside the site's range.
+	ReasonDirModerateOff = "Wind off direction"
+	// ReasonDirMarginal explains the penalty for wind just outside the site's range.
+	ReasonDirMarginal = "Wind slightly off direction"
+	// ReasonGustHigh explains the penalty for a dangerous gust factor.
+	ReasonGustHigh = "Very gusty"
+	// ReasonGustMed explains the penalty for a high gust factor.
+	ReasonGustMed = "Gusty"
+	// ReasonGradientHigh explains the penalty for a high wind gradient.
+	ReasonGradientHigh = "High wind gradient"
+	// ReasonGradientMed explains the penalty for a medium wind gradient.
+	ReasonGradientMed = "Medium wind gradient"
+	// ReasonRain explains the penalty for forecast rain.
+	ReasonRain = "Rain"
+	// ReasonRainLikely explains the penalty for a high chance of rain.
+	ReasonRainLikely = "Rain likely"
+	// ReasonRainPossible explains the penalty for a moderate chance of rain.
+	ReasonRainPossible = "Rain possible"
+	// ReasonCAPE explains the bonus for useful CAPE.
+	ReasonCAPE = "Useful CAPE"
+	// ReasonThermals explains the bonus for moderate or strong thermals.
+	ReasonThermals = "Good thermals"
+	// ReasonStormCap explains a score capped by storm risk.
+	ReasonStormCap = "%s storm risk"
+)
+
+// Model comparison confidence ratings.
+const (
+	// ConfidenceHigh indicates the weather models broadly agree.
+	ConfidenceHigh = "High"
+	// ConfidenceMedium indicates the models disagree on one aspect of the forecast.
+	ConfidenceMedium = "Medium"
+	// ConfidenceLow indicates the models disagree on several aspects of the forecast.
+	ConfidenceLow = "Low"
+)
+
 // Cloudbase display strings.
 const (
+	// CloudbaseMethodProfile indicates cloudbase was found by lifting a parcel through the pressure-level profile.
+	CloudbaseMethodProfile = "profile"
+	// CloudbaseMethodSurface indicates cloudbase was estimated from the surface temperature/dewpoint spread only.
+	CloudbaseMethodSurface = "surface"
 	// CloudbaseFog indicates conditions of fog or zero cloudbase.
 	CloudbaseFog = "Fog"
 )
@@ -86,6 +188,10 @@ const (
 	LabelElev = "Elev:"
 	// LabelGenerated is the label for the forecast generation timestamp.
 	LabelGenerated = "Generated:"
+	// LabelCached marks a forecast built from a cached Open-Meteo response.
+	LabelCached = "(cached)"
+	// LabelModel is the label for the weather model used for the forecast.
+	LabelModel = "Model:"
 )
 
 // Column headers for detailed forecast.
@@ -100,12 +206,16 @@ const (
 	HeaderGradient = "Gradient"
 	// HeaderThermal is the column header for thermal strength rating.
 	HeaderThermal = "Thermal"
+	// HeaderClimb is the column header for the expected thermal climb rate (m/s).
+	HeaderClimb = "m/s"
 	// HeaderCloud is the column header for cloud or cloudbase information.
 	HeaderCloud = "Cloud"
 	// HeaderRain is the column header for precipitation chance or intensity.
 	HeaderRain = "Rain"
 	// HeaderScore is the column header for the overall site or time-slot score.
 	HeaderScore = "Score"
+	// HeaderWarnings is the column header for the hour's hazard warning icons.
+	HeaderWarnings = "Warnings"
 )
 
 // Column headers for extended outlook.
@@ -132,10 +242,76 @@ const (
 	ForecastTitle = "🪂 PARAGLIDING FORECAST — %s"
 	// BestWindowLabel is the label used to show the best flying window.
 	BestWindowLabel = "🏆 Best Window: %s"
-	// CloudbaseLabel is the format string for summarising cloudbase, CAPE, and freezing level.
-	CloudbaseLabel = "Cloudbase: ~%s | CAPE: %.0f J/kg | Freezing: %.0fft"
+	// CloudbaseLabel is the format string for summarising cloudbase, thermal top, CAPE, and freezing level.
+	CloudbaseLabel = "Cloudbase: ~%s (%dft AMSL) | Thermals to: ~%dft | CAPE: %.0f J/kg | Freezing: %.0fft"
+	// WindDirLabel is the format string for the day's average wind direction and its variability.
+	WindDirLabel = "Wind direction: %s ±%.0f°%s"
+	// LabelVariable flags a day whose wind direction veers or backs significantly.
+	LabelVariable = "🔄 variable"
+	// ThermalStrengthLabel is the format string for the day's best expected climb rate.
+	ThermalStrengthLabel = "Best climb: ~%.1f m/s"
+	// ScoreBreakdownTitle is the title of the --explain score breakdown.
+	ScoreBreakdownTitle = "🧮 SCORE BREAKDOWN — %s"
+	// LabelBase labels the base flyability score in a breakdown.
+	LabelBase = "base"
+	// LabelCappedBy is the format string noting why a score was capped.
+	LabelCappedBy = "capped by %s"
+	// LabelLaunches is the format string naming the best launch among every launch's score.
+	LabelLaunches = "best launch %s of %s"
+	// WarningsTitle heads the list of hourly warnings under the forecast table.
+	WarningsTitle = "Warnings:"
+	// OverdevelopmentLabel is the format string for the day's overdevelopment warning.
+	OverdevelopmentLabel = "⛈ Overdevelopment expected after %s"
 	// OrographicLabel is the format string for describing orographic lift conditions.
 	OrographicLabel = "Orographic: %s"
+	// InversionLabel is the format string for the inversion base and strength.
+	InversionLabel = "Inversion: %dft AMSL (+%.1f°C)%s"
+	// LabelCapsThermals marks an inversion that caps the thermals.
+	LabelCapsThermals = " — caps thermals"
 	// XCLabel is the format string for describing cross-country potential.
 	XCLabel = "XC Potential: %s %s"
+	// CompareTitle is the formatted title line for a multi-model comparison.
+	CompareTitle = "🔀 MODEL COMPARISON — %s"
+	// LabelModels is the label listing the models in a comparison.
+	LabelModels = "Models:"
+	// ProfileCompareTitle is the formatted title line for a pilot profile comparison.
+	ProfileCompareTitle = "🎚 PILOT PROFILES — %s"
+	// LabelProfiles is the label listing the profiles in a comparison.
+	LabelProfiles = "Profiles:"
+)
+
+// Column headers for model comparison.
+const (
+	// HeaderSpread is the column header for the spread between models.
+	HeaderSpread = "±"
+	// HeaderConfidence is the column header for model agreement confidence.
+	HeaderConfidence = "Confidence"
+)
+
+// Sites file validation messages.
+const (
+	// SiteMissingMsg reports a required site field that is absent.
+	SiteMissingMsg = "is missing"
+	// SiteDuplicateMsg reports a site name already used, with the line it was first used on.
+	SiteDuplicateMsg = "duplicate site name, first defined on line %d"
+	// SiteRangeMsg reports a value outside its allowed range.
+	SiteRangeMsg = "%g is outside %g to %g"
+	// SiteIgnoredMsg reports a flat launch field that is ignored because the site lists launches.
+	SiteIgnoredMsg = "is ignored because the site lists launches"
+	// SiteOutsideWindRangeMsg reports an aspect or best direction outside the launch's wind range.
+	SiteOutsideWindRangeMsg = "%d° is outside the wind range %d-%d"
+)
+
+// Tuning config validation messages.
+const (
+	// TuningBelowMsg reports a value below the value it must be at least, named by its config path.
+	TuningBelowMsg = "%g is below %s (%g)"
+	// TuningMinMsg reports a value below its minimum.
+	TuningMinMsg = "%g is below %g"
+	// TuningRangeMsg reports a value outside its allowed range.
+	TuningRangeMsg = "%g is outside %g to %g"
+	// TuningBonusMsg reports a negative bonus.
+	TuningBonusMsg = "%g is a bonus and must not be negative"
+	// TuningPenaltyMsg reports a positive penalty.
+	TuningPenaltyMsg = "%g is a penalty and must not be positive"
 )
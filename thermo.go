@@ -0,0 +1,238 @@
+package pgforecast
+
+import (
+	"math"
+	"sort"
+)
+
+// Thermodynamic constants.
+const (
+	// KelvinOffset converts °C to K.
+	KelvinOffset = 273.15
+
+	// Gravity is standard gravitational acceleration (m/s²).
+	Gravity = 9.80665
+
+	// GasConstantDryAir is the specific gas constant of dry air (J/kg/K).
+	GasConstantDryAir = 287.04
+
+	// SpecificHeatDryAir is the specific heat of dry air at constant pressure (J/kg/K).
+	SpecificHeatDryAir = 1005.7
+
+	// LatentHeatVaporisation is the latent heat of vaporisation of water (J/kg).
+	LatentHeatVaporisation = 2.501e6
+
+	// MolecularWeightRatio is the ratio of the molecular weights of water vapour and dry air.
+	MolecularWeightRatio = 0.622
+
+	// DryAdiabaticLapseRate is the cooling rate (°C/km) of a rising unsaturated parcel.
+	DryAdiabaticLapseRate = Gravity / SpecificHeatDryAir * MetersPerKm
+
+	// StandardAirDensity is the sea-level air density (kg/m³) of the standard atmosphere.
+	StandardAirDensity = 1.225
+
+	// ParcelStepM is the vertical step (m) used when lifting a parcel through a profile.
+	ParcelStepM = 25.0
+)
+
+// SaturationVapourPressure returns the saturation vapour pressure (hPa) over
+// water at the given temperature (°C), using Bolton's formula.
+func SaturationVapourPressure(tempC float64) float64 {
+	return 6.112 * math.Exp(17.67*tempC/(tempC+243.5))
+}
+
+// SaturationMixingRatio returns the saturation mixing ratio (kg/kg) at the
+// given temperature (°C) and pressure (hPa).
+func SaturationMixingRatio(tempC, pressure float64) float64 {
+	e := SaturationVapourPressure(tempC)
+	if e >= pressure {
+		e = pressure * 0.99
+	}
+	return MolecularWeightRatio * e / (pressure - e)
+}
+
+// DewPointFromMixingRatio returns the dew point (°C) of air with mixing ratio
+// w (kg/kg) at the given pressure (hPa).
+func DewPointFromMixingRatio(w, pressure float64) float64 {
+	e := w * pressure / (MolecularWeightRatio + w)
+	l := math.Log(e / 6.112)
+	return 243.5 * l / (17.67 - l)
+}
+
+// MoistAdiabaticLapseRate returns the cooling rate (°C/km) of a rising
+// saturated parcel at the given temperature (°C) and pressure (hPa).
+func MoistAdiabaticLapseRate(tempC, pressure float64) float64 {
+	t := tempC + KelvinOffset
+	rs := SaturationMixingRatio(tempC, pressure)
+	num := 1 + LatentHeatVaporisation*rs/(GasConstantDryAir*t)
+	den := SpecificHeatDryAir + LatentHeatVaporisation*LatentHeatVaporisation*rs*MolecularWeightRatio/(GasConstantDryAir*t*t)
+	return Gravity * num / den * MetersPerKm
+}
+
+// profilePoint is one level of an environmental temperature profile.
+type profilePoint struct {
+	HeightM     float64 // geopotential height AMSL
+	Pressure    float64 // hPa
+	Temperature float64 // °C
+	DewPoint    float64 // °C; NaN when unknown
+}
+
+// atmosphereProfile is an environmental sounding above a site, ordered by
+// increasing height and starting at the surface.
+type atmosphereProfile struct {
+	points []profilePoint
+	// pressureRef holds every pressure level (including those below ground)
+	// for interpolating pressure at any height.
+	pressureRef []profilePoint
+}
+
+// buildProfile assembles the environmental profile above a surface at
+// elevationM from surface observations and pressure levels. ok is false when
+// fewer than two pressure levels with heights are available, in which case
+// callers should fall back to surface-only estimates.
+func buildProfile(h *HourlyData, elevationM float64) (p atmosphereProfile, ok bool) {
+	for _, l := range h.PressureLevels {
+		if l.GeopotentialHeight <= 0 || l.Pressure <= 0 {
+			continue
+		}
+		dew := math.NaN()
+		if l.RelativeHumidity > 0 {
+			dew = l.DewPoint
+		}
+		p.pressureRef = append(p.pressureRef, profilePoint{
+			HeightM:     l.GeopotentialHeight,
+			Pressure:    float64(l.Pressure),
+			Temperature: l.Temperature,
+			DewPoint:    dew,
+		})
+	}
+	if len(p.pressureRef) < 2 {
+		return p, false
+	}
+	sort.Slice(p.pressureRef, func(i, j int) bool { return p.pressureRef[i].HeightM < p.pressureRef[j].HeightM })
+
+	p.points = append(p.points, profilePoint{
+		HeightM:     elevationM,
+		Pressure:    p.pressureAt(elevationM),
+		Temperature: h.Temperature,
+		DewPoint:    h.DewPoint,
+	})
+	for _, l := range p.pressureRef {
+		if l.HeightM > elevationM {
+			p.points = append(p.points, l)
+		}
+	}
+	return p, len(p.points) >= 2
+}
+
+// pressureAt interpolates pressure (hPa) at a height, linear in log-pressure.
+func (p atmosphereProfile) pressureAt(z float64) float64 {
+	ref := p.pressureRef
+	i := sort.Search(len(ref), func(i int) bool { return ref[i].HeightM >= z })
+	switch {
+	case i == 0:
+		i = 1
+	case i == len(ref):
+		i = len(ref) - 1
+	}
+	a, b := ref[i-1], ref[i]
+	f := (z - a.HeightM) / (b.HeightM - a.HeightM)
+	return math.Exp(math.Log(a.Pressure) + f*(math.Log(b.Pressure)-math.Log(a.Pressure)))
+}
+
+// temperatureAt interpolates the environmental temperature (°C) at a height
+// within the profile. ok is false above the top of the profile.
+func (p atmosphereProfile) temperatureAt(z float64) (float64, bool) {
+	pts := p.points
+	if z > pts[len(pts)-1].HeightM {
+		return 0, false
+	}
+	i := sort.Search(len(pts), func(i int) bool { return pts[i].HeightM >= z })
+	if i == 0 {
+		return pts[0].Temperature, true
+	}
+	a, b := pts[i-1], pts[i]
+	f := (z - a.HeightM) / (b.HeightM - a.HeightM)
+	return a.Temperature + f*(b.Temperature-a.Temperature), true
+}
+
+// surface returns the lowest point of the profile.
+func (p atmosphereProfile) surface() profilePoint {
+	return p.points[0]
+}
+
+// top returns the height of the highest point of the profile.
+func (p atmosphereProfile) top() float64 {
+	return p.points[len(p.points)-1].HeightM
+}
+
+// liftedCondensationLevel lifts a surface parcel dry-adiabatically, conserving
+// its mixing ratio, and returns the height AMSL (m) at which it saturates.
+func (p atmosphereProfile) liftedCondensationLevel() float64 {
+	s := p.surface()
+	w := SaturationMixingRatio(s.DewPoint, s.Pressure)
+	for z := s.HeightM; ; z += ParcelStepM {
+		t := s.Temperature - DryAdiabaticLapseRate*(z-s.HeightM)/MetersPerKm
+		if SaturationMixingRatio(t, p.pressureAt(z)) <= w {
+			return z
+		}
+		if z-s.HeightM > 10*MetersPerKm {
+			return z
+		}
+	}
+}
+
+// dryThermalTop returns the height AMSL (m) at which a surface parcel warmed by
+// triggerC and lifted dry-adiabatically becomes cooler than the environment.
+// When the parcel stays buoyant through the whole profile, the profile top is
+// returned.
+func (p atmosphereProfile) dryThermalTop(triggerC float64) float64 {
+	s := p.surface()
+	for z := s.HeightM; ; z += ParcelStepM {
+		env, ok := p.temperatureAt(z)
+		if !ok {
+			return p.top()
+		}
+		parcel := s.Temperature + triggerC - DryAdiabaticLapseRate*(z-s.HeightM)/MetersPerKm
+		if parcel < env {
+			return z
+		}
+	}
+}
+
+// stableLayer is a layer of the profile that suppresses convection.
+type stableLayer struct {
+	BaseM, TopM float64 // AMSL
+	LapseRate   float64 // °C/km; negative in an inversion
+	StrengthC   float64 // temperature rise from base to top
+}
+
+// stableLayers scans the profile for layers with a lapse rate below
+// stableLapseRate (°C/km), merging adjacent stable segments. Layers are
+// returned in order of increasing height.
+func (p atmosphereProfile) stableLayers(stableLapseRate float64) []stableLayer {
+	var layers []stableLayer
+	for i := 1; i < len(p.points); i++ {
+		a, b := p.points[i-1], p.points[i]
+		dz := b.HeightM - a.HeightM
+		if dz <= 0 {
+			continue
+		}
+		if (a.Temperature-b.Temperature)/(dz/MetersPerKm) >= stableLapseRate {
+			continue
+		}
+		if n := len(layers); n > 0 && layers[n-1].TopM == a.HeightM {
+			layers[n-1].TopM = b.HeightM
+		} else {
+			layers = append(layers, stableLayer{BaseM: a.HeightM, TopM: b.HeightM})
+		}
+	}
+	for i := range layers {
+		l := &layers[i]
+		base, _ := p.temperatureAt(l.BaseM)
+		top, _ := p.temperatureAt(l.TopM)
+		l.StrengthC = top - base
+		l.LapseRate = (base - top) / ((l.TopM - l.BaseM) / MetersPerKm)
+	}
+	return layers
+}
@@ -1,5 +1,12 @@
 package pgforecast
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // WindStrengthTier defines display properties for a wind speed range.
 type WindStrengthTier struct {
 	RGB   string `mapstructure:"rgb" yaml:"rgb" json:"rgb"`
@@ -79,13 +86,51 @@ type TuningConfig struct {
 	} `mapstructure:"gradient" yaml:"gradient" json:"gradient"`
 
 	Thermal struct {
-		CAPEWeak       float64 `mapstructure:"cape_weak" yaml:"cape_weak" json:"cape_weak"`
-		CAPEModerate   float64 `mapstructure:"cape_moderate" yaml:"cape_moderate" json:"cape_moderate"`
-		CAPEStrong     float64 `mapstructure:"cape_strong" yaml:"cape_strong" json:"cape_strong"`
-		CAPEExtreme    float64 `mapstructure:"cape_extreme" yaml:"cape_extreme" json:"cape_extreme"`
-		LapseRateBonus float64 `mapstructure:"lapse_rate_bonus" yaml:"lapse_rate_bonus" json:"lapse_rate_bonus"`
+		CAPEWeak        float64 `mapstructure:"cape_weak" yaml:"cape_weak" json:"cape_weak"`
+		CAPEModerate    float64 `mapstructure:"cape_moderate" yaml:"cape_moderate" json:"cape_moderate"`
+		CAPEStrong      float64 `mapstructure:"cape_strong" yaml:"cape_strong" json:"cape_strong"`
+		CAPEExtreme     float64 `mapstructure:"cape_extreme" yaml:"cape_extreme" json:"cape_extreme"`
+		LapseRateBonus  float64 `mapstructure:"lapse_rate_bonus" yaml:"lapse_rate_bonus" json:"lapse_rate_bonus"`
+		StableLapseRate float64 `mapstructure:"stable_lapse_rate" yaml:"stable_lapse_rate" json:"stable_lapse_rate"`
+		InversionMinC   float64 `mapstructure:"inversion_min_c" yaml:"inversion_min_c" json:"inversion_min_c"`
+		CeilingWeakFt   int     `mapstructure:"ceiling_weak_ft" yaml:"ceiling_weak_ft" json:"ceiling_weak_ft"`
+		CeilingNoneFt   int     `mapstructure:"ceiling_none_ft" yaml:"ceiling_none_ft" json:"ceiling_none_ft"`
 	} `mapstructure:"thermal" yaml:"thermal" json:"thermal"`
 
+	ThermalStrength struct {
+		SensibleHeatFraction float64 `mapstructure:"sensible_heat_fraction" yaml:"sensible_heat_fraction" json:"sensible_heat_fraction"`
+		SinkRateMS           float64 `mapstructure:"sink_rate_ms" yaml:"sink_rate_ms" json:"sink_rate_ms"`
+	} `mapstructure:"thermal_strength" yaml:"thermal_strength" json:"thermal_strength"`
+
+	Convection struct {
+		CINModerate           float64 `mapstructure:"cin_moderate" yaml:"cin_moderate" json:"cin_moderate"`
+		CINStrong             float64 `mapstructure:"cin_strong" yaml:"cin_strong" json:"cin_strong"`
+		LIUnstable            float64 `mapstructure:"li_unstable" yaml:"li_unstable" json:"li_unstable"`
+		LIStable              float64 `mapstructure:"li_stable" yaml:"li_stable" json:"li_stable"`
+		ShallowBoundaryLayerM float64 `mapstructure:"shallow_boundary_layer_m" yaml:"shallow_boundary_layer_m" json:"shallow_boundary_layer_m"`
+		DeepBoundaryLayerM    float64 `mapstructure:"deep_boundary_layer_m" yaml:"deep_boundary_layer_m" json:"deep_boundary_layer_m"`
+		MinSunshineFraction   float64 `mapstructure:"min_sunshine_fraction" yaml:"min_sunshine_fraction" json:"min_sunshine_fraction"`
+	} `mapstructure:"convection" yaml:"convection" json:"convection"`
+
+	Storm struct {
+		CAPEModerate         float64 `mapstructure:"cape_moderate" yaml:"cape_moderate" json:"cape_moderate"`
+		CAPEHigh             float64 `mapstructure:"cape_high" yaml:"cape_high" json:"cape_high"`
+		LIModerate           float64 `mapstructure:"li_moderate" yaml:"li_moderate" json:"li_moderate"`
+		LIHigh               float64 `mapstructure:"li_high" yaml:"li_high" json:"li_high"`
+		MidLevelRH           float64 `mapstructure:"mid_level_rh" yaml:"mid_level_rh" json:"mid_level_rh"`
+		PrecipProb           float64 `mapstructure:"precip_prob" yaml:"precip_prob" json:"precip_prob"`
+		ShowerLookaheadHours int     `mapstructure:"shower_lookahead_hours" yaml:"shower_lookahead_hours" json:"shower_lookahead_hours"`
+		ModerateMaxScore     int     `mapstructure:"moderate_max_score" yaml:"moderate_max_score" json:"moderate_max_score"`
+		HighMaxScore         int     `mapstructure:"high_max_score" yaml:"high_max_score" json:"high_max_score"`
+	} `mapstructure:"storm" yaml:"storm" json:"storm"`
+
+	Warnings struct {
+		LowCloudbaseFt  int     `mapstructure:"low_cloudbase_ft" yaml:"low_cloudbase_ft" json:"low_cloudbase_ft"`
+		LowVisibilityM  float64 `mapstructure:"low_visibility_m" yaml:"low_visibility_m" json:"low_visibility_m"`
+		PoorVisibilityM float64 `mapstructure:"poor_visibility_m" yaml:"poor_visibility_m" json:"poor_visibility_m"`
+		RotorAngle      float64 `mapstructure:"rotor_angle" yaml:"rotor_angle" json:"rotor_angle"`
+	} `mapstructure:"warnings" yaml:"warnings" json:"warnings"`
+
 	Orographic struct {
 		MinWindSpeed  float64 `mapstructure:"min_wind_speed" yaml:"min_wind_speed" json:"min_wind_speed"`
 		StrongAngle   float64 `mapstructure:"strong_angle" yaml:"strong_angle" json:"strong_angle"`
@@ -94,7 +139,8 @@ type TuningConfig struct {
 	} `mapstructure:"orographic" yaml:"orographic" json:"orographic"`
 
 	Cloudbase struct {
-		MinRealisticFt int `mapstructure:"min_realistic_ft" yaml:"min_realistic_ft" json:"min_realistic_ft"`
+		MinRealisticFt  int     `mapstructure:"min_realistic_ft" yaml:"min_realistic_ft" json:"min_realistic_ft"`
+		ThermalTriggerC float64 `mapstructure:"thermal_trigger_c" yaml:"thermal_trigger_c" json:"thermal_trigger_c"`
 	} `mapstructure:"cloudbase" yaml:"cloudbase" json:"cloudbase"`
 
 	Scoring struct {
@@ -126,6 +172,13 @@ type TuningConfig struct {
 		HighThreshold   int     `mapstructure:"high_threshold" yaml:"high_threshold" json:"high_threshold"`
 		MediumThreshold int     `mapstructure:"medium_threshold" yaml:"medium_threshold" json:"medium_threshold"`
 	} `mapstructure:"xc" yaml:"xc" json:"xc"`
+
+	Ensemble struct {
+		WindSpeedSpread float64 `mapstructure:"wind_speed_spread" yaml:"wind_speed_spread" json:"wind_speed_spread"`
+		WindDirSpread   float64 `mapstructure:"wind_dir_spread" yaml:"wind_dir_spread" json:"wind_dir_spread"`
+		ScoreSpread     int     `mapstructure:"score_spread" yaml:"score_spread" json:"score_spread"`
+		ThermalSpread   int     `mapstructure:"thermal_spread" yaml:"thermal_spread" json:"thermal_spread"`
+	} `mapstructure:"ensemble" yaml:"ensemble" json:"ensemble"`
 }
 
 // DefaultTuningConfig returns the default tuning configuration.
@@ -150,6 +203,36 @@ func DefaultTuningConfig() *TuningConfig {
 	tc.Thermal.CAPEStrong = 1000
 	tc.Thermal.CAPEExtreme = 2500
 	tc.Thermal.LapseRateBonus = 8.0
+	tc.Thermal.StableLapseRate = 3.0
+	tc.Thermal.InversionMinC = 0.5
+	tc.Thermal.CeilingWeakFt = 1500
+	tc.Thermal.CeilingNoneFt = 300
+
+	tc.ThermalStrength.SensibleHeatFraction = 0.3
+	tc.ThermalStrength.SinkRateMS = 1.0
+
+	tc.Convection.CINModerate = 50
+	tc.Convection.CINStrong = 150
+	tc.Convection.LIUnstable = -2
+	tc.Convection.LIStable = 4
+	tc.Convection.ShallowBoundaryLayerM = 500
+	tc.Convection.DeepBoundaryLayerM = 1500
+	tc.Convection.MinSunshineFraction = 0.25
+
+	tc.Storm.CAPEModerate = 1000
+	tc.Storm.CAPEHigh = 2000
+	tc.Storm.LIModerate = -3
+	tc.Storm.LIHigh = -6
+	tc.Storm.MidLevelRH = 70
+	tc.Storm.PrecipProb = 40
+	tc.Storm.ShowerLookaheadHours = 2
+	tc.Storm.ModerateMaxScore = 2
+	tc.Storm.HighMaxScore = ScoreMin
+
+	tc.Warnings.LowCloudbaseFt = 1000
+	tc.Warnings.LowVisibilityM = 5000
+	tc.Warnings.PoorVisibilityM = 1500
+	tc.Warnings.RotorAngle = 120
 
 	tc.Orographic.MinWindSpeed = 8
 	tc.Orographic.StrongAngle = 15
@@ -157,6 +240,7 @@ func DefaultTuningConfig() *TuningConfig {
 	tc.Orographic.WeakAngle = 45
 
 	tc.Cloudbase.MinRealisticFt = 200
+	tc.Cloudbase.ThermalTriggerC = 1.0
 
 	tc.Scoring.BaseScore = 2.5
 	tc.Scoring.WindIdealBonus = 1.0
@@ -182,6 +266,11 @@ func DefaultTuningConfig() *TuningConfig {
 	tc.XC.HighThreshold = 5
 	tc.XC.MediumThreshold = 3
 
+	tc.Ensemble.WindSpeedSpread = 6
+	tc.Ensemble.WindDirSpread = 45
+	tc.Ensemble.ScoreSpread = 1
+	tc.Ensemble.ThermalSpread = 1
+
 	// Display defaults
 	tc.Display.WindStrength.Light = WindStrengthTier{RGB: "#4fd1c5", Label: "Light", Icon: "💤"}
 	tc.Display.WindStrength.Moderate = WindStrengthTier{RGB: "#48bb78", Label: "Moderate", Icon: "✅"}
@@ -195,3 +284,270 @@ func DefaultTuningConfig() *TuningConfig {
 
 	return tc
 }
+
+// TuningOverrides is a partial tuning config keyed like the tuning YAML, e.g.
+// {"wind": {"ideal_min": 12}}. Only the values given are overridden.
+type TuningOverrides map[string]any
+
+// WithOverrides returns a copy of tc with the overrides deep-merged over it.
+// An unknown section or key is an error, so typos are not silently ignored.
+func (tc *TuningConfig) WithOverrides(o TuningOverrides) (*TuningConfig, error) {
+	data, err := json.Marshal(tc)
+	if err != nil {
+		return nil, err
+	}
+	var merged map[string]any
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	if err := mergeOverrides(merged, o, ""); err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(merged); err != nil {
+		return nil, err
+	}
+
+	out := &TuningConfig{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return nil, fmt.Errorf("applying tuning overrides: %w", err)
+	}
+	return out, nil
+}
+
+// mergeOverrides deep-merges src into dst, both decoded from tuning JSON.
+// path is the dotted prefix of dst's keys, for error messages.
+func mergeOverrides(dst, src map[string]any, path string) error {
+	for k, v := range src {
+		key := path + k
+		cur, ok := dst[k]
+		if !ok {
+			return fmt.Errorf("unknown tuning key %q", key)
+		}
+		curSection, isSection := cur.(map[string]any)
+		srcSection, setsSection := asSection(v)
+		switch {
+		case isSection && setsSection:
+			if err := mergeOverrides(curSection, srcSection, key+"."); err != nil {
+				return err
+			}
+		case isSection:
+			return fmt.Errorf("tuning key %q is a section, not a value", key)
+		case setsSection:
+			return fmt.Errorf("tuning key %q is a value, not a section", key)
+		default:
+			dst[k] = v
+		}
+	}
+	return nil
+}
+
+// asSection reports whether an override value is a nested section. YAML
+// decodes nested sections of a TuningOverrides as TuningOverrides too.
+func asSection(v any) (map[string]any, bool) {
+	switch m := v.(type) {
+	case map[string]any:
+		return m, true
+	case TuningOverrides:
+		return m, true
+	}
+	return nil, false
+}
+
+// SiteTuning returns the tuning config in effect for a site: tc with the
+// site's overrides merged over it. A nil tc means DefaultTuningConfig. When
+// the site has no overrides tc itself is returned. The result must pass
+// Validate, so every forecast is computed from a consistent config.
+func SiteTuning(site Site, tc *TuningConfig) (*TuningConfig, error) {
+	if tc == nil {
+		tc = DefaultTuningConfig()
+	}
+	out := tc
+	if len(site.Tuning) > 0 {
+		var err error
+		if out, err = tc.WithOverrides(site.Tuning); err != nil {
+			return nil, fmt.Errorf("tuning for site %s: %w", site.Name, err)
+		}
+	}
+	if err := out.Validate(); err != nil {
+		if len(site.Tuning) > 0 {
+			return nil, fmt.Errorf("tuning for site %s: %w", site.Name, err)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// TuningProblem is one inconsistent or out-of-range tuning value.
+type TuningProblem struct {
+	Path    string `json:"path"` // config path, e.g. "wind.ideal_max"
+	Message string `json:"message"`
+}
+
+// String formats the problem as "path: message".
+func (p TuningProblem) String() string {
+	return p.Path + ": " + p.Message
+}
+
+// TuningError lists every problem found by TuningConfig.Validate.
+type TuningError struct {
+	Problems []TuningProblem
+}
+
+func (e *TuningError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = "  " + p.String()
+	}
+	return fmt.Sprintf("invalid tuning config (%d problems):\n%s", len(e.Problems), strings.Join(lines, "\n"))
+}
+
+// tuningValue is a tuning value with its config path.
+type tuningValue struct {
+	path  string
+	value float64
+}
+
+// tuningValidator collects the problems found in a tuning config.
+type tuningValidator struct {
+	problems []TuningProblem
+}
+
+func (v *tuningValidator) add(path, format string, args ...any) {
+	v.problems = append(v.problems, TuningProblem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// ordered checks that each value is at least the one before it.
+func (v *tuningValidator) ordered(values ...tuningValue) {
+	for i := 1; i < len(values); i++ {
+		prev, cur := values[i-1], values[i]
+		if cur.value < prev.value {
+			v.add(cur.path, TuningBelowMsg, cur.value, prev.path, prev.value)
+		}
+	}
+}
+
+func (v *tuningValidator) atLeast(min float64, values ...tuningValue) {
+	for _, tv := range values {
+		if tv.value < min {
+			v.add(tv.path, TuningMinMsg, tv.value, min)
+		}
+	}
+}
+
+func (v *tuningValidator) between(min, max float64, values ...tuningValue) {
+	for _, tv := range values {
+		if tv.value < min || tv.value > max {
+			v.add(tv.path, TuningRangeMsg, tv.value, min, max)
+		}
+	}
+}
+
+// bonuses checks that values added to the score are not negative.
+func (v *tuningValidator) bonuses(values ...tuningValue) {
+	for _, tv := range values {
+		if tv.value < 0 {
+			v.add(tv.path, TuningBonusMsg, tv.value)
+		}
+	}
+}
+
+// penalties checks that values added to the score are not positive.
+func (v *tuningValidator) penalties(values ...tuningValue) {
+	for _, tv := range values {
+		if tv.value > 0 {
+			v.add(tv.path, TuningPenaltyMsg, tv.value)
+		}
+	}
+}
+
+// Validate checks that the thresholds in each section are in order (e.g.
+// wind.ideal_min ≤ wind.ideal_max, thermal.cape_strong ≥ cape_moderate),
+// that bonuses and penalties have the right sign, and that angles, fractions
+// and percentages are in range. It returns a *TuningError listing every
+// problem, or nil.
+func (tc *TuningConfig) Validate() error {
+	var v tuningValidator
+	f := func(path string, value float64) tuningValue { return tuningValue{path, value} }
+	i := func(path string, value int) tuningValue { return tuningValue{path, float64(value)} }
+
+	w := tc.Wind
+	v.atLeast(0, f("wind.acceptable_min", w.AcceptableMin))
+	v.ordered(f("wind.acceptable_min", w.AcceptableMin), f("wind.ideal_min", w.IdealMin), f("wind.ideal_max", w.IdealMax),
+		f("wind.acceptable_max", w.AcceptableMax), f("wind.dangerous_max", w.DangerousMax))
+	v.atLeast(1, f("wind.max_gust_factor", w.MaxGustFactor))
+	v.ordered(f("wind.max_gust_factor", w.MaxGustFactor), f("wind.dangerous_gust_factor", w.DangerousGustFactor))
+
+	g := tc.Gradient
+	v.atLeast(0, f("gradient.low_threshold", g.LowThreshold))
+	v.ordered(f("gradient.low_threshold", g.LowThreshold), f("gradient.high_threshold", g.HighThreshold))
+	v.penalties(f("gradient.high_penalty", g.HighPenalty), f("gradient.medium_penalty", g.MediumPenalty))
+	v.ordered(f("gradient.high_penalty", g.HighPenalty), f("gradient.medium_penalty", g.MediumPenalty))
+
+	t := tc.Thermal
+	v.atLeast(0, f("thermal.cape_weak", t.CAPEWeak), f("thermal.inversion_min_c", t.InversionMinC), i("thermal.ceiling_none_ft", t.CeilingNoneFt))
+	v.ordered(f("thermal.cape_weak", t.CAPEWeak), f("thermal.cape_moderate", t.CAPEModerate),
+		f("thermal.cape_strong", t.CAPEStrong), f("thermal.cape_extreme", t.CAPEExtreme))
+	v.ordered(f("thermal.stable_lapse_rate", t.StableLapseRate), f("thermal.lapse_rate_bonus", t.LapseRateBonus))
+	v.ordered(i("thermal.ceiling_none_ft", t.CeilingNoneFt), i("thermal.ceiling_weak_ft", t.CeilingWeakFt))
+
+	ts := tc.ThermalStrength
+	v.between(0, 1, f("thermal_strength.sensible_heat_fraction", ts.SensibleHeatFraction))
+	v.atLeast(0, f("thermal_strength.sink_rate_ms", ts.SinkRateMS))
+
+	c := tc.Convection
+	v.atLeast(0, f("convection.cin_moderate", c.CINModerate), f("convection.shallow_boundary_layer_m", c.ShallowBoundaryLayerM))
+	v.ordered(f("convection.cin_moderate", c.CINModerate), f("convection.cin_strong", c.CINStrong))
+	v.ordered(f("convection.li_unstable", c.LIUnstable), f("convection.li_stable", c.LIStable))
+	v.ordered(f("convection.shallow_boundary_layer_m", c.ShallowBoundaryLayerM), f("convection.deep_boundary_layer_m", c.DeepBoundaryLayerM))
+	v.between(0, 1, f("convection.min_sunshine_fraction", c.MinSunshineFraction))
+
+	s := tc.Storm
+	v.ordered(f("storm.cape_moderate", s.CAPEModerate), f("storm.cape_high", s.CAPEHigh))
+	v.ordered(f("storm.li_high", s.LIHigh), f("storm.li_moderate", s.LIModerate))
+	v.between(0, 100, f("storm.mid_level_rh", s.MidLevelRH), f("storm.precip_prob", s.PrecipProb))
+	v.between(0, 24, i("storm.shower_lookahead_hours", s.ShowerLookaheadHours))
+	v.between(ScoreMin, ScoreMax, i("storm.high_max_score", s.HighMaxScore), i("storm.moderate_max_score", s.ModerateMaxScore))
+	v.ordered(i("storm.high_max_score", s.HighMaxScore), i("storm.moderate_max_score", s.ModerateMaxScore))
+
+	wn := tc.Warnings
+	v.atLeast(0, i("warnings.low_cloudbase_ft", wn.LowCloudbaseFt), f("warnings.poor_visibility_m", wn.PoorVisibilityM))
+	v.ordered(f("warnings.poor_visibility_m", wn.PoorVisibilityM), f("warnings.low_visibility_m", wn.LowVisibilityM))
+	v.between(0, DegreesHalfCircle, f("warnings.rotor_angle", wn.RotorAngle))
+
+	o := tc.Orographic
+	v.atLeast(0, f("orographic.min_wind_speed", o.MinWindSpeed))
+	v.between(0, DegreesHalfCircle, f("orographic.strong_angle", o.StrongAngle), f("orographic.moderate_angle", o.ModerateAngle), f("orographic.weak_angle", o.WeakAngle))
+	v.ordered(f("orographic.strong_angle", o.StrongAngle), f("orographic.moderate_angle", o.ModerateAngle), f("orographic.weak_angle", o.WeakAngle))
+
+	v.atLeast(0, i("cloudbase.min_realistic_ft", tc.Cloudbase.MinRealisticFt))
+
+	sc := tc.Scoring
+	v.between(ScoreMin, ScoreMax, f("scoring.base_score", sc.BaseScore))
+	v.bonuses(f("scoring.wind_ideal_bonus", sc.WindIdealBonus), f("scoring.wind_acceptable_bonus", sc.WindAcceptableBonus),
+		f("scoring.dir_on_bonus", sc.DirOnBonus), f("scoring.cape_bonus", sc.CAPEBonus), f("scoring.thermal_strong_bonus", sc.ThermalStrongBonus))
+	v.ordered(f("scoring.wind_acceptable_bonus", sc.WindAcceptableBonus), f("scoring.wind_ideal_bonus", sc.WindIdealBonus))
+	v.penalties(f("scoring.wind_danger_penalty", sc.WindDangerPenalty), f("scoring.wind_high_penalty", sc.WindHighPenalty),
+		f("scoring.dir_off_penalty", sc.DirOffPenalty), f("scoring.gust_high_penalty", sc.GustHighPenalty), f("scoring.gust_med_penalty", sc.GustMedPenalty),
+		f("scoring.rain_penalty", sc.RainPenalty), f("scoring.rain_prob_penalty", sc.RainProbPenalty),
+		f("scoring.gradient_high_penalty", sc.GradientHighPenalty), f("scoring.gradient_med_penalty", sc.GradientMedPenalty))
+	v.ordered(f("scoring.wind_danger_penalty", sc.WindDangerPenalty), f("scoring.wind_high_penalty", sc.WindHighPenalty))
+	v.ordered(f("scoring.gust_high_penalty", sc.GustHighPenalty), f("scoring.gust_med_penalty", sc.GustMedPenalty))
+	v.ordered(f("scoring.rain_penalty", sc.RainPenalty), f("scoring.rain_prob_penalty", sc.RainProbPenalty))
+	v.ordered(f("scoring.gradient_high_penalty", sc.GradientHighPenalty), f("scoring.gradient_med_penalty", sc.GradientMedPenalty))
+
+	x := tc.XC
+	v.ordered(i("xc.min_cloudbase_ft", x.MinCloudbaseFt), i("xc.good_cloudbase_ft", x.GoodCloudbaseFt))
+	v.ordered(f("xc.min_wind_speed", x.MinWindSpeed), f("xc.max_wind_speed", x.MaxWindSpeed))
+	v.ordered(i("xc.medium_threshold", x.MediumThreshold), i("xc.high_threshold", x.HighThreshold), i("xc.epic_threshold", x.EpicThreshold))
+
+	e := tc.Ensemble
+	v.atLeast(0, f("ensemble.wind_speed_spread", e.WindSpeedSpread), i("ensemble.score_spread", e.ScoreSpread), i("ensemble.thermal_spread", e.ThermalSpread))
+	v.between(0, DegreesHalfCircle, f("ensemble.wind_dir_spread", e.WindDirSpread))
+
+	if len(v.problems) > 0 {
+		return &TuningError{Problems: v.problems}
+	}
+	return nil
+}
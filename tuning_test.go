@@ -1,6 +1,10 @@
 package pgforecast
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+)
 
 func TestDefaultDisplayConfig(t *testing.T) {
 	tc := DefaultTuningConfig()
@@ -78,3 +82,97 @@ func TestGradientIcon(t *testing.T) {
 		t.Errorf("GradientIcon(High) = %q, want 🔴", tc.GradientIcon(GradientHigh))
 	}
 }
+
+func TestWithOverrides(t *testing.T) {
+	tc := DefaultTuningConfig()
+	out, err := tc.WithOverrides(TuningOverrides{
+		"wind":    map[string]any{"ideal_min": 12, "max_gust_factor": 1.3},
+		"display": map[string]any{"gradient": map[string]any{"high": map[string]any{"icon": "🚫"}}},
+	})
+	if err != nil {
+		t.Fatalf("WithOverrides: %v", err)
+	}
+	if out.Wind.IdealMin != 12 || out.Wind.MaxGustFactor != 1.3 {
+		t.Errorf("wind = %+v, want overrides applied", out.Wind)
+	}
+	if out.Wind.IdealMax != tc.Wind.IdealMax || out.Scoring != tc.Scoring {
+		t.Error("values not overridden should keep the global config")
+	}
+	if out.Display.Gradient.High.Icon != "🚫" || out.Display.Gradient.High.RGB != tc.Display.Gradient.High.RGB {
+		t.Errorf("display.gradient.high = %+v, want only the icon overridden", out.Display.Gradient.High)
+	}
+	if tc.Wind.IdealMin != DefaultTuningConfig().Wind.IdealMin {
+		t.Error("WithOverrides modified the receiver")
+	}
+
+	for name, o := range map[string]TuningOverrides{
+		"unknown section":   {"wnd": map[string]any{"ideal_min": 12}},
+		"unknown key":       {"wind": map[string]any{"ideal": 12}},
+		"value for section": {"wind": 12},
+		"section for value": {"wind": map[string]any{"ideal_min": map[string]any{"x": 1}}},
+		"wrong type":        {"wind": map[string]any{"ideal_min": "twelve"}},
+	} {
+		if _, err := tc.WithOverrides(o); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
+
+func TestSiteTuning(t *testing.T) {
+	tc := DefaultTuningConfig()
+	if got, err := SiteTuning(Site{Name: "Plain"}, tc); err != nil || got != tc {
+		t.Errorf("site without overrides = %p, %v; want the global config unchanged", got, err)
+	}
+	if got, err := SiteTuning(Site{}, nil); err != nil || got.Wind != DefaultTuningConfig().Wind {
+		t.Errorf("nil config = %v, %v; want the defaults", got, err)
+	}
+	_, err := SiteTuning(Site{Name: "Typo", Tuning: TuningOverrides{"wind": map[string]any{"idea_min": 1}}}, tc)
+	if err == nil || !strings.Contains(err.Error(), "Typo") || !strings.Contains(err.Error(), "wind.idea_min") {
+		t.Errorf("err = %v, want the site name and key path", err)
+	}
+	_, err = SiteTuning(Site{Name: "Inverted", Tuning: TuningOverrides{"wind": map[string]any{"ideal_max": 4}}}, tc)
+	var tuningErr *TuningError
+	if !errors.As(err, &tuningErr) || !strings.Contains(err.Error(), "Inverted") {
+		t.Errorf("err = %v, want a TuningError naming the site", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := DefaultTuningConfig().Validate(); err != nil {
+		t.Errorf("default config: %v", err)
+	}
+	for _, p := range PilotProfiles {
+		if err := p.Apply(DefaultTuningConfig()).Validate(); err != nil {
+			t.Errorf("%s profile: %v", p.Name, err)
+		}
+	}
+
+	tc := DefaultTuningConfig()
+	tc.Wind.IdealMax = 6
+	tc.Thermal.CAPEStrong = 200
+	tc.XC.EpicThreshold = 4
+	tc.Scoring.RainPenalty = 1
+	tc.Storm.HighMaxScore = 0
+	tc.Warnings.RotorAngle = 200
+
+	var tuningErr *TuningError
+	if err := tc.Validate(); !errors.As(err, &tuningErr) {
+		t.Fatalf("Validate() = %v, want a TuningError", err)
+	}
+	want := []string{
+		"wind.ideal_max",
+		"thermal.cape_strong",
+		"storm.high_max_score",
+		"warnings.rotor_angle",
+		"scoring.rain_penalty",
+		"scoring.rain_prob_penalty", // now below the positive rain_penalty
+		"xc.epic_threshold",
+	}
+	var got []string
+	for _, p := range tuningErr.Problems {
+		got = append(got, p.Path)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("problem paths = %v, want %v", got, want)
+	}
+}
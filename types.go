@@ -12,6 +12,33 @@ type Site struct {
 	WindMax   int     `yaml:"wind_max" json:"wind_max"`
 	BestDir   int     `yaml:"best_dir" json:"best_dir"`
 	Aspect    int     `yaml:"aspect" json:"aspect"`
+
+	// Launches lists the site's take-offs when it has more than one. Each hour
+	// is scored for every launch and the best is reported. Without launches
+	// the flat fields above describe the only launch; LoadSites fills them
+	// from the first launch otherwise.
+	Launches []Launch `yaml:"launches,omitempty" json:"launches,omitempty"`
+
+	// Tuning overrides parts of the global tuning config for this site only,
+	// e.g. wider wind ranges for a coastal soaring site. See SiteTuning.
+	Tuning TuningOverrides `yaml:"tuning,omitempty" json:"tuning,omitempty"`
+}
+
+// Launch is one take-off at a site, facing its own way.
+type Launch struct {
+	Name      string `yaml:"name" json:"name"`
+	Elevation int    `yaml:"elevation" json:"elevation"` // 0 uses the site's elevation
+	WindMin   int    `yaml:"wind_min" json:"wind_min"`
+	WindMax   int    `yaml:"wind_max" json:"wind_max"`
+	BestDir   int    `yaml:"best_dir" json:"best_dir"`
+	Aspect    int    `yaml:"aspect" json:"aspect"`
+}
+
+// LaunchScore holds one launch's flyability score for an hour.
+type LaunchScore struct {
+	Launch          string  `json:"launch"`
+	FlyabilityScore int     `json:"flyability_score"`
+	FlyabilityExact float64 `json:"flyability_score_exact"`
 }
 
 // SitesConfig is the top-level YAML structure.
@@ -26,79 +53,146 @@ type PressureLevel struct {
 	WindDirection      float64 `json:"wind_direction"`
 	Temperature        float64 `json:"temperature"`
 	GeopotentialHeight float64 `json:"geopotential_height"`
+	RelativeHumidity   float64 `json:"relative_humidity"`
+	DewPoint           float64 `json:"dew_point"`
+	CloudCover         float64 `json:"cloud_cover"`
+	VerticalVelocity   float64 `json:"vertical_velocity"` // m/s, positive upwards
 }
 
 // HourlyData holds all weather data for one hour.
 type HourlyData struct {
-	Time                     time.Time       `json:"time"`
-	Temperature              float64         `json:"temperature_2m"`
-	RelativeHumidity         float64         `json:"relative_humidity_2m"`
-	DewPoint                 float64         `json:"dew_point_2m"`
-	WindSpeed                float64         `json:"wind_speed_10m"`
-	WindDirection            float64         `json:"wind_direction_10m"`
-	WindGusts                float64         `json:"wind_gusts_10m"`
-	CloudCover               float64         `json:"cloud_cover"`
-	CloudCoverLow            float64         `json:"cloud_cover_low"`
-	CloudCoverMid            float64         `json:"cloud_cover_mid"`
-	CloudCoverHigh           float64         `json:"cloud_cover_high"`
-	CAPE                     float64         `json:"cape"`
-	ShortwaveRadiation       float64         `json:"shortwave_radiation"`
-	Precipitation            float64         `json:"precipitation"`
-	PrecipitationProbability float64         `json:"precipitation_probability"`
-	FreezingLevelHeight      float64         `json:"freezing_level_height"`
-	IsDay                    int             `json:"is_day"`
-	WeatherCode              int             `json:"weather_code"`
-	PressureMSL              float64         `json:"pressure_msl"`
-	Visibility               float64         `json:"visibility"`
-	PressureLevels           []PressureLevel `json:"pressure_levels"`
+	Time                     time.Time `json:"time"`
+	Temperature              float64   `json:"temperature_2m"`
+	RelativeHumidity         float64   `json:"relative_humidity_2m"`
+	DewPoint                 float64   `json:"dew_point_2m"`
+	WindSpeed                float64   `json:"wind_speed_10m"`
+	WindDirection            float64   `json:"wind_direction_10m"`
+	WindGusts                float64   `json:"wind_gusts_10m"`
+	CloudCover               float64   `json:"cloud_cover"`
+	CloudCoverLow            float64   `json:"cloud_cover_low"`
+	CloudCoverMid            float64   `json:"cloud_cover_mid"`
+	CloudCoverHigh           float64   `json:"cloud_cover_high"`
+	CAPE                     float64   `json:"cape"`
+	ShortwaveRadiation       float64   `json:"shortwave_radiation"`
+	Precipitation            float64   `json:"precipitation"`
+	PrecipitationProbability float64   `json:"precipitation_probability"`
+	FreezingLevelHeight      float64   `json:"freezing_level_height"`
+	IsDay                    int       `json:"is_day"`
+	WeatherCode              int       `json:"weather_code"`
+	PressureMSL              float64   `json:"pressure_msl"`
+	Visibility               float64   `json:"visibility"`
+
+	// Convective parameters. Not every model provides them; a missing value
+	// is 0, which the metrics treat as neutral. A sunless hour is not
+	// neutral, so a missing sunshine duration is nil instead.
+	BoundaryLayerHeight float64         `json:"boundary_layer_height"` // m above model ground
+	LiftedIndex         float64         `json:"lifted_index"`          // °C; negative is unstable
+	CIN                 float64         `json:"convective_inhibition"` // J/kg, as a positive magnitude
+	SunshineDuration    *float64        `json:"sunshine_duration"`     // seconds of sunshine in the hour
+	PressureLevels      []PressureLevel `json:"pressure_levels"`
+}
+
+// Warning is a safety hazard flagged for one hour.
+type Warning struct {
+	Type     string `json:"type"`     // gusts, wind, wind_aloft, gradient, direction, rotor, rain, cloudbase, visibility, storm
+	Severity string `json:"severity"` // Caution/Danger
+	Message  string `json:"message"`
+}
+
+// ScoreContribution is one bonus or penalty applied to the flyability score.
+type ScoreContribution struct {
+	Reason string  `json:"reason"`
+	Key    string  `json:"key,omitempty"` // TuningConfig path of the value, e.g. scoring.dir_on_bonus; empty when fixed
+	Value  float64 `json:"value"`
+}
+
+// ScoreBreakdown explains how a flyability score was reached.
+type ScoreBreakdown struct {
+	Base          float64             `json:"base"`
+	Contributions []ScoreContribution `json:"contributions"`
+	Raw           float64             `json:"raw"`                 // base plus contributions, before rounding and clamping
+	Exact         float64             `json:"exact"`               // the final score before rounding
+	Score         int                 `json:"score"`               // the final FlyabilityScore
+	CappedBy      string              `json:"capped_by,omitempty"` // why Score is below the clamped raw score
 }
 
 // HourlyMetrics holds computed paragliding metrics for one hour.
 type HourlyMetrics struct {
-	Time             time.Time       `json:"time"`
-	WindSpeed        float64         `json:"wind_speed"`
-	WindDirection    float64         `json:"wind_direction"`
-	WindDirStr       string          `json:"wind_dir_str"`
-	WindGusts        float64         `json:"wind_gusts"`
-	WindGradient     string          `json:"wind_gradient"` // Low/Medium/High
-	WindGradientDiff float64         `json:"wind_gradient_diff"`
-	ThermalRating    string          `json:"thermal_rating"` // None/Weak/Moderate/Strong/Extreme
-	CAPE             float64         `json:"cape"`
-	CAPERating       string          `json:"cape_rating"`
-	CloudbaseFt      int             `json:"cloudbase_ft"`
-	CloudCover       float64         `json:"cloud_cover"`
-	Precipitation    float64         `json:"precipitation"`
-	PrecipProb       float64         `json:"precip_probability"`
-	OrographicLift   string          `json:"orographic_lift"`  // None/Weak/Moderate/Strong
-	FlyabilityScore  int             `json:"flyability_score"` // 1-5
-	XCPotential      string          `json:"xc_potential"`     // Low/Medium/High/Epic
-	FreezingLevel    float64         `json:"freezing_level_ft"`
-	IsDay            bool            `json:"is_day"`
-	PressureLevels   []PressureLevel `json:"pressure_levels"`
+	Time                time.Time       `json:"time"`
+	WindSpeed           float64         `json:"wind_speed"`
+	WindDirection       float64         `json:"wind_direction"`
+	WindDirStr          string          `json:"wind_dir_str"`
+	WindGusts           float64         `json:"wind_gusts"`
+	WindGradient        string          `json:"wind_gradient"` // Low/Medium/High
+	WindGradientDiff    float64         `json:"wind_gradient_diff"`
+	ThermalRating       string          `json:"thermal_rating"`      // None/Weak/Moderate/Strong/Extreme
+	ThermalStrengthMS   float64         `json:"thermal_strength_ms"` // expected climb rate
+	CAPE                float64         `json:"cape"`
+	CAPERating          string          `json:"cape_rating"`
+	StormRisk           string          `json:"storm_risk"` // None/Low/Moderate/High
+	LiftedIndex         float64         `json:"lifted_index"`
+	CIN                 float64         `json:"convective_inhibition"`
+	BoundaryLayerFt     int             `json:"boundary_layer_ft"` // 0 when the model does not provide it
+	CloudbaseFt         int             `json:"cloudbase_ft"`      // above launch
+	CloudbaseAMSLFt     int             `json:"cloudbase_amsl_ft"`
+	ThermalTopFt        int             `json:"thermal_top_ft"` // above launch
+	ThermalTopAMSLFt    int             `json:"thermal_top_amsl_ft"`
+	CloudbaseMethod     string          `json:"cloudbase_method"`  // profile/surface
+	InversionBaseFt     int             `json:"inversion_base_ft"` // above launch
+	InversionBaseAMSLFt int             `json:"inversion_base_amsl_ft"`
+	InversionStrengthC  float64         `json:"inversion_strength_c"` // 0 when there is no inversion
+	ThermalsCapped      bool            `json:"thermals_capped"`      // thermal top limited by the inversion
+	StableLayers        []StableLayer   `json:"stable_layers"`
+	CloudCover          float64         `json:"cloud_cover"`
+	Precipitation       float64         `json:"precipitation"`
+	PrecipProb          float64         `json:"precip_probability"`
+	OrographicLift      string          `json:"orographic_lift"`        // None/Weak/Moderate/Strong
+	FlyabilityScore     int             `json:"flyability_score"`       // 1-5; capped, down to 0, by storm risk
+	FlyabilityExact     float64         `json:"flyability_score_exact"` // unrounded FlyabilityScore, for ranking
+	ScoreBreakdown      ScoreBreakdown  `json:"score_breakdown"`
+	XCPotential         string          `json:"xc_potential"` // Low/Medium/High/Epic
+	FreezingLevel       float64         `json:"freezing_level_ft"`
+	IsDay               bool            `json:"is_day"`
+	Launch              string          `json:"launch,omitempty"`        // best launch, for sites with several
+	LaunchScores        []LaunchScore   `json:"launch_scores,omitempty"` // every launch's score, in site order
+	Warnings            []Warning       `json:"warnings"`
+	PressureLevels      []PressureLevel `json:"pressure_levels"`
 }
 
 // DaySummary holds aggregated metrics for extended outlook days.
 type DaySummary struct {
-	Date          time.Time `json:"date"`
-	AvgWindSpeed  float64   `json:"avg_wind_speed"`
-	AvgWindDir    float64   `json:"avg_wind_direction"`
-	WindDirStr    string    `json:"wind_dir_str"`
-	MaxGusts      float64   `json:"max_gusts"`
-	ThermalRating string    `json:"thermal_rating"`
-	MaxPrecipProb float64   `json:"max_precip_prob"`
-	AvgCloudbase  int       `json:"avg_cloudbase_ft"`
-	BestScore     int       `json:"best_score"`
-	XCPotential   string    `json:"xc_potential"`
+	Date                 time.Time `json:"date"`
+	AvgWindSpeed         float64   `json:"avg_wind_speed"`
+	AvgWindDir           float64   `json:"avg_wind_direction"`
+	WindDirStr           string    `json:"wind_dir_str"`
+	WindDirVariability   float64   `json:"wind_dir_variability"` // circular std dev of wind direction (°)
+	MaxGusts             float64   `json:"max_gusts"`
+	ThermalRating        string    `json:"thermal_rating"`
+	MaxThermalStrength   float64   `json:"max_thermal_strength_ms"`
+	MaxPrecipProb        float64   `json:"max_precip_prob"`
+	StormRisk            string    `json:"storm_risk"`            // highest hourly storm risk
+	OverdevelopmentAfter string    `json:"overdevelopment_after"` // HH:MM from OverdevelopmentOnset, or empty
+	AvgCloudbase         int       `json:"avg_cloudbase_ft"`
+	AvgThermalTop        int       `json:"avg_thermal_top_ft"`
+	BestScore            int       `json:"best_score"`
+	BestScoreExact       float64   `json:"best_score_exact"` // unrounded average of the top 3 hours
+	XCPotential          string    `json:"xc_potential"`
 }
 
 // SiteForecast holds the complete forecast for one site.
 type SiteForecast struct {
 	Site         Site          `json:"site"`
-	Generated    time.Time     `json:"generated"`
+	Generated    time.Time     `json:"generated"` // when the weather data was fetched from Open-Meteo
+	FromCache    bool          `json:"from_cache"`
 	Units        string        `json:"units"`
+	Model        WeatherModel  `json:"model"`
 	DetailedDays []DayForecast `json:"detailed_days"`
 	ExtendedDays []DaySummary  `json:"extended_days"`
 	BestWindow   string        `json:"best_window"`
+
+	// Tuning is the config the forecast was computed with, site overrides
+	// and pilot profile included.
+	Tuning *TuningConfig `json:"-"`
 }
 
 // DayForecast holds hourly metrics for one day.
@@ -113,8 +207,13 @@ type ForecastOptions struct {
 	Units        string // mph, kph, knots, ms
 	DetailedDays int
 	Timezone     string
-	Model        string
-	OutputFormat string // text, json
+	Model        string   // auto, gfs, ecmwf, icon, ukmo, ... (see WeatherModels)
+	OutputFormat string   // text, json
 	HTTPClient   HTTPDoer // optional; if nil, a standard http.Client with 30s timeout is used. A typed-nil (e.g., (*http.Client)(nil)) is treated as nil and falls back to the default.
 	Tuning       *TuningConfig
+	Profile      string        // pilot profile applied over each site's tuning; empty for none (see ProfileTuning)
+	Scorer       Scorer        // optional; if nil, DefaultScorer is used (see ResolveScorer)
+	Cache        ResponseCache // optional; if nil, every call fetches from Open-Meteo
+	RefreshCache bool          // bypass cached responses but still store fresh ones
+	Concurrency  int           // max Open-Meteo requests in flight in GenerateForecasts; <= 0 uses DefaultConcurrency
 }
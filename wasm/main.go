@@ -4,16 +4,24 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
 	"syscall/js"
+	"time"
 
 	"github.com/matt-FFFFFF/pgforecast"
+	"github.com/matt-FFFFFF/pgforecast/sounding"
 )
 
 func main() {
 	js.Global().Set("pgforecastWasm", js.ValueOf(map[string]interface{}{
-		"computeMetrics":  js.FuncOf(computeMetrics),
-		"defaultTuning":   js.FuncOf(defaultTuning),
+		"applyProfile":     js.FuncOf(applyProfile),
+		"computeMetrics":   js.FuncOf(computeMetrics),
+		"defaultTuning":    js.FuncOf(defaultTuning),
 		"degreesToCompass": js.FuncOf(degreesToCompass),
+		"pilotProfiles":    js.FuncOf(pilotProfiles),
+		"renderSounding":   js.FuncOf(renderSounding),
+		"validateTuning":   js.FuncOf(validateTuning),
 	}))
 
 	// Keep alive
@@ -48,6 +56,11 @@ func computeMetrics(_ js.Value, args []js.Value) interface{} {
 			return jsError("parsing tuning: " + err.Error())
 		}
 	}
+	// Site overrides from sites.json apply over the user's tuning.
+	tc, err := pgforecast.SiteTuning(site, tc)
+	if err != nil {
+		return jsError(err.Error())
+	}
 
 	// Parse weather data (raw Open-Meteo JSON response)
 	weatherData, err := pgforecast.ParseOpenMeteoJSON([]byte(weatherJSON))
@@ -90,12 +103,106 @@ func computeMetrics(_ js.Value, args []js.Value) interface{} {
 	return string(out)
 }
 
+// renderSounding renders the skew-T for one hour as SVG.
+// Called from JS: pgforecastWasm.renderSounding(weatherJSON, siteJSON, timeISO, tuningJSON, emagram)
+// Returns {"svg": "..."} or {"error": "..."}.
+func renderSounding(_ js.Value, args []js.Value) interface{} {
+	if len(args) < 3 {
+		return jsError("need at least 3 args: weatherJSON, siteJSON, timeISO")
+	}
+
+	var site pgforecast.Site
+	if err := json.Unmarshal([]byte(args[1].String()), &site); err != nil {
+		return jsError("parsing site: " + err.Error())
+	}
+
+	at, err := time.Parse(time.RFC3339, args[2].String())
+	if err != nil {
+		return jsError("parsing time: " + err.Error())
+	}
+
+	tc := pgforecast.DefaultTuningConfig()
+	if len(args) >= 4 && !args[3].IsUndefined() && !args[3].IsNull() {
+		if err := json.Unmarshal([]byte(args[3].String()), tc); err != nil {
+			return jsError("parsing tuning: " + err.Error())
+		}
+	}
+	if tc, err = pgforecast.SiteTuning(site, tc); err != nil {
+		return jsError(err.Error())
+	}
+	opts := sounding.Options{Units: "mph"} // web/js/weather.js requests mph
+	if len(args) >= 5 && !args[4].IsUndefined() && !args[4].IsNull() {
+		opts.Emagram = args[4].Truthy()
+	}
+
+	weatherData, err := pgforecast.ParseOpenMeteoJSON([]byte(args[0].String()))
+	if err != nil {
+		return jsError("parsing weather: " + err.Error())
+	}
+	for i := range weatherData {
+		if !weatherData[i].Time.Equal(at) {
+			continue
+		}
+		var sb strings.Builder
+		if err := sounding.Render(&sb, &weatherData[i], site, tc, opts); err != nil {
+			return jsError(err.Error())
+		}
+		out, _ := json.Marshal(map[string]string{"svg": sb.String()})
+		return string(out)
+	}
+	return jsError("no weather data for " + args[2].String())
+}
+
 func defaultTuning(_ js.Value, _ []js.Value) interface{} {
 	tc := pgforecast.DefaultTuningConfig()
 	out, _ := json.Marshal(tc)
 	return string(out)
 }
 
+// pilotProfiles returns the pilot profiles as a JSON array of names and descriptions.
+func pilotProfiles(_ js.Value, _ []js.Value) interface{} {
+	out, _ := json.Marshal(pgforecast.PilotProfiles)
+	return string(out)
+}
+
+// applyProfile adjusts a tuning config for a pilot profile and returns it as JSON.
+// Called from JS: pgforecastWasm.applyProfile(tuningJSON, profileName)
+func applyProfile(_ js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return jsError("need 2 args: tuningJSON, profileName")
+	}
+	tc := pgforecast.DefaultTuningConfig()
+	if err := json.Unmarshal([]byte(args[0].String()), tc); err != nil {
+		return jsError("parsing tuning: " + err.Error())
+	}
+	p, err := pgforecast.ResolveProfile(args[1].String())
+	if err != nil {
+		return jsError(err.Error())
+	}
+	out, _ := json.Marshal(p.Apply(tc))
+	return string(out)
+}
+
+// validateTuning checks a tuning config and returns its problems as a JSON
+// array of {path, message}, empty when the config is valid.
+// Called from JS: pgforecastWasm.validateTuning(tuningJSON)
+func validateTuning(_ js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return jsError("need 1 arg: tuningJSON")
+	}
+	tc := pgforecast.DefaultTuningConfig()
+	if err := json.Unmarshal([]byte(args[0].String()), tc); err != nil {
+		return jsError("parsing tuning: " + err.Error())
+	}
+	problems := []pgforecast.TuningProblem{}
+	var tuningErr *pgforecast.TuningError
+	if errors.As(tc.Validate(), &tuningErr) {
+		problems = tuningErr.Problems
+	}
+	out, _ := json.Marshal(problems)
+	return string(out)
+}
+
 func degreesToCompass(_ js.Value, args []js.Value) interface{} {
 	if len(args) < 1 {
 		return ""
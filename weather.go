@@ -1,15 +1,18 @@
 package pgforecast
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -27,7 +30,9 @@ const maxResponseSize = 10 * 1024 * 1024
 // ErrResponseTooLarge is returned when the API response exceeds maxResponseSize.
 var ErrResponseTooLarge = errors.New("response body exceeds size limit")
 
-var pressureLevels = []int{1000, 950, 925, 900, 850, 700}
+// pressureLevels are the upper-air levels (hPa) requested from Open-Meteo,
+// dense enough below 600 hPa (~4200m) for thermal analysis.
+var pressureLevels = []int{1000, 975, 950, 925, 900, 875, 850, 800, 750, 700, 650, 600, 500}
 
 var surfaceParams = []string{
 	"temperature_2m", "relative_humidity_2m", "dew_point_2m",
@@ -35,6 +40,7 @@ var surfaceParams = []string{
 	"cloud_cover", "cloud_cover_low", "cloud_cover_mid", "cloud_cover_high",
 	"cape", "shortwave_radiation", "precipitation", "precipitation_probability",
 	"freezing_level_height", "is_day", "weather_code", "pressure_msl", "visibility",
+	"boundary_layer_height", "lifted_index", "convective_inhibition", "sunshine_duration",
 }
 
 func windSpeedUnit(units string) string {
@@ -57,20 +63,209 @@ func FetchWeather(site Site, opts ForecastOptions) ([]HourlyData, error) {
 
 // FetchWeatherWithContext fetches weather data from Open-Meteo with context support for cancellation.
 func FetchWeatherWithContext(ctx context.Context, site Site, opts ForecastOptions) ([]HourlyData, error) {
+	data, _, _, err := fetchWeather(ctx, site, opts)
+	return data, err
+}
+
+// maxBatchLocations is the most sites sent to Open-Meteo in one request.
+const maxBatchLocations = 20
+
+// fetchResult holds the weather data fetched for one site.
+type fetchResult struct {
+	data      []HourlyData
+	fetchedAt time.Time
+	fromCache bool
+	err       error
+}
+
+// FetchWeatherBatch fetches weather data for many sites, requesting up to
+// maxBatchLocations sites per Open-Meteo call. The result is aligned with
+// sites. An error is returned if any site could not be fetched.
+func FetchWeatherBatch(ctx context.Context, sites []Site, opts ForecastOptions) ([][]HourlyData, error) {
+	results := fetchWeatherBatch(ctx, sites, opts)
+	out := make([][]HourlyData, len(results))
+	for i, r := range results {
+		if r.err != nil {
+			return nil, fmt.Errorf("fetching weather for %s: %w", sites[i].Name, r.err)
+		}
+		out[i] = r.data
+	}
+	return out, nil
+}
+
+// fetchWeather fetches and parses weather data for a site, consulting
+// opts.Cache when set. It also returns when the data was fetched from
+// Open-Meteo and whether it was served from the cache.
+func fetchWeather(ctx context.Context, site Site, opts ForecastOptions) ([]HourlyData, time.Time, bool, error) {
+	r := fetchWeatherBatch(ctx, []Site{site}, opts)[0]
+	return r.data, r.fetchedAt, r.fromCache, r.err
+}
+
+// fetchWeatherBatch fetches weather data for several sites. Cached responses
+// are used where available; the remaining sites are requested in chunks of
+// maxBatchLocations, up to opts.Concurrency chunks at a time. Each site's
+// response is cached individually under its single-site request URL.
+func fetchWeatherBatch(ctx context.Context, sites []Site, opts ForecastOptions) []fetchResult {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	results := make([]fetchResult, len(sites))
+	fail := func(err error) []fetchResult {
+		for i := range results {
+			results[i].err = err
+		}
+		return results
+	}
+	if err := ctx.Err(); err != nil {
+		return fail(err)
+	}
+	model, err := ResolveModel(opts.Model)
+	if err != nil {
+		return fail(err)
+	}
+
+	var misses []int
+	for i, site := range sites {
+		if opts.Cache != nil && !opts.RefreshCache {
+			if body, fetchedAt, ok := opts.Cache.Get(forecastURL([]Site{site}, model, opts)); ok {
+				data, err := ParseOpenMeteoJSON(body)
+				if err == nil {
+					results[i] = fetchResult{data: data, fetchedAt: fetchedAt, fromCache: true}
+					continue
+				}
+				// A corrupt entry is treated as a miss and overwritten below.
+			}
+		}
+		misses = append(misses, i)
+	}
+
+	limit := opts.Concurrency
+	if limit <= 0 {
+		limit = DefaultConcurrency
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for start := 0; start < len(misses); start += maxBatchLocations {
+		end := start + maxBatchLocations
+		if end > len(misses) {
+			end = len(misses)
+		}
+		chunk := misses[start:end]
+		wg.Add(1)
+		go func(chunk []int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fetchChunk(ctx, sites, chunk, model, opts, results)
+		}(chunk)
+	}
+	wg.Wait()
+	return results
+}
+
+// fetchChunk requests the sites at the given indices in a single call and
+// stores each site's outcome in results.
+func fetchChunk(ctx context.Context, sites []Site, idx []int, model WeatherModel, opts ForecastOptions, results []fetchResult) {
+	chunk := make([]Site, len(idx))
+	for i, j := range idx {
+		chunk[i] = sites[j]
+	}
+	fail := func(err error) {
+		for _, j := range idx {
+			results[j].err = err
+		}
+	}
+
+	body, err := doFetch(ctx, forecastURL(chunk, model, opts), maxResponseSize*len(chunk), opts)
+	if err != nil {
+		fail(err)
+		return
+	}
+	fetchedAt := time.Now()
+
+	parts, err := splitBatchResponse(body)
+	if err != nil {
+		fail(err)
+		return
+	}
+	if len(parts) != len(chunk) {
+		fail(fmt.Errorf("API returned %d locations, want %d", len(parts), len(chunk)))
+		return
+	}
+	for i, j := range idx {
+		data, err := ParseOpenMeteoJSON(parts[i])
+		if err != nil {
+			results[j].err = err
+			continue
+		}
+		results[j] = fetchResult{data: data, fetchedAt: fetchedAt}
+		if opts.Cache != nil {
+			// Caching is best-effort; a failed write must not fail the forecast.
+			_ = opts.Cache.Set(forecastURL([]Site{chunk[i]}, model, opts), parts[i], fetchedAt)
+		}
+	}
+}
+
+// forecastURL builds the Open-Meteo request URL for one or more sites. For a
+// single site it doubles as the response cache key.
+func forecastURL(sites []Site, model WeatherModel, opts ForecastOptions) string {
+	lats := make([]string, len(sites))
+	lons := make([]string, len(sites))
+	for i, s := range sites {
+		lats[i] = fmt.Sprintf("%.4f", s.Lat)
+		lons[i] = fmt.Sprintf("%.4f", s.Lon)
+	}
 	u, _ := url.Parse("https://api.open-meteo.com/v1/forecast")
 	q := u.Query()
-	q.Set("latitude", fmt.Sprintf("%.4f", site.Lat))
-	q.Set("longitude", fmt.Sprintf("%.4f", site.Lon))
+	q.Set("latitude", strings.Join(lats, ","))
+	q.Set("longitude", strings.Join(lons, ","))
 	q.Set("hourly", buildHourlyParams())
 	q.Set("wind_speed_unit", windSpeedUnit(opts.Units))
 	q.Set("forecast_days", "16")
 	q.Set("timezone", "UTC")
+	if model.Name != ModelAuto {
+		q.Set("models", model.OpenMeteoID)
+	}
 	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// splitBatchResponse splits an Open-Meteo response into one raw JSON object
+// per location. Multi-location requests return an array; single-location
+// requests return a bare object.
+func splitBatchResponse(body []byte) ([]json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var parts []json.RawMessage
+		if err := json.Unmarshal(trimmed, &parts); err != nil {
+			return nil, fmt.Errorf("decoding response: %w", err)
+		}
+		return parts, nil
+	}
+	return []json.RawMessage{trimmed}, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+// ParseOpenMeteoBatchJSON parses a multi-location Open-Meteo JSON response
+// (an array of per-location objects, or a single object) into HourlyData per
+// location, in request order.
+func ParseOpenMeteoBatchJSON(rawJSON []byte) ([][]HourlyData, error) {
+	parts, err := splitBatchResponse(rawJSON)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]HourlyData, len(parts))
+	for i, p := range parts {
+		if out[i], err = ParseOpenMeteoJSON(p); err != nil {
+			return nil, fmt.Errorf("location %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
+
+// doFetch performs the HTTP request and returns the response body, which may
+// be at most limit bytes.
+func doFetch(ctx context.Context, rawURL string, limit int, opts ForecastOptions) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -90,19 +285,14 @@ func FetchWeatherWithContext(ctx context.Context, site Site, opts ForecastOption
 		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
 	}
 
-	limitedBody := &io.LimitedReader{R: resp.Body, N: maxResponseSize + 1}
-	var raw map[string]interface{}
-	if err := json.NewDecoder(limitedBody).Decode(&raw); err != nil {
-		if limitedBody.N <= 0 {
-			return nil, fmt.Errorf("%w (%d bytes)", ErrResponseTooLarge, maxResponseSize)
-		}
-		return nil, fmt.Errorf("decoding response: %w", err)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(limit)+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
 	}
-	if limitedBody.N <= 0 {
-		return nil, fmt.Errorf("%w (%d bytes)", ErrResponseTooLarge, maxResponseSize)
+	if len(body) > limit {
+		return nil, fmt.Errorf("%w (%d bytes)", ErrResponseTooLarge, limit)
 	}
-
-	return parseHourlyData(raw)
+	return body, nil
 }
 
 // ParseOpenMeteoJSON parses a raw Open-Meteo JSON response into HourlyData.
@@ -124,6 +314,10 @@ func buildHourlyParams() string {
 			fmt.Sprintf("wind_direction_%dhPa", p),
 			fmt.Sprintf("temperature_%dhPa", p),
 			fmt.Sprintf("geopotential_height_%dhPa", p),
+			fmt.Sprintf("relative_humidity_%dhPa", p),
+			fmt.Sprintf("dew_point_%dhPa", p),
+			fmt.Sprintf("cloud_cover_%dhPa", p),
+			fmt.Sprintf("vertical_velocity_%dhPa", p),
 		)
 	}
 	return strings.Join(params, ",")
@@ -146,6 +340,11 @@ func parseHourlyData(raw map[string]interface{}) ([]HourlyData, error) {
 		result[i].Time = ts
 	}
 
+	has := func(key string, i int) bool {
+		arr, ok := hourly[key].([]interface{})
+		return ok && i < len(arr) && arr[i] != nil
+	}
+
 	getFloat := func(key string, i int) float64 {
 		arr, ok := hourly[key].([]interface{})
 		if !ok || i >= len(arr) { return 0 }
@@ -176,14 +375,29 @@ func parseHourlyData(raw map[string]interface{}) ([]HourlyData, error) {
 		d.WeatherCode = int(getFloat("weather_code", i))
 		d.PressureMSL = getFloat("pressure_msl", i)
 		d.Visibility = getFloat("visibility", i)
+		d.BoundaryLayerHeight = getFloat("boundary_layer_height", i)
+		d.LiftedIndex = getFloat("lifted_index", i)
+		d.CIN = math.Abs(getFloat("convective_inhibition", i))
+		if has("sunshine_duration", i) {
+			sun := getFloat("sunshine_duration", i)
+			d.SunshineDuration = &sun
+		}
 
 		for _, p := range pressureLevels {
+			// Not every model provides every level; skip levels with no data.
+			if !has(fmt.Sprintf("geopotential_height_%dhPa", p), i) && !has(fmt.Sprintf("wind_speed_%dhPa", p), i) {
+				continue
+			}
 			pl := PressureLevel{
 				Pressure:          p,
 				WindSpeed:         getFloat(fmt.Sprintf("wind_speed_%dhPa", p), i),
 				WindDirection:     getFloat(fmt.Sprintf("wind_direction_%dhPa", p), i),
 				Temperature:       getFloat(fmt.Sprintf("temperature_%dhPa", p), i),
 				GeopotentialHeight: getFloat(fmt.Sprintf("geopotential_height_%dhPa", p), i),
+				RelativeHumidity:   getFloat(fmt.Sprintf("relative_humidity_%dhPa", p), i),
+				DewPoint:           getFloat(fmt.Sprintf("dew_point_%dhPa", p), i),
+				CloudCover:         getFloat(fmt.Sprintf("cloud_cover_%dhPa", p), i),
+				VerticalVelocity:   getFloat(fmt.Sprintf("vertical_velocity_%dhPa", p), i),
 			}
 			d.PressureLevels = append(d.PressureLevels, pl)
 		}
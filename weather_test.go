@@ -201,3 +201,138 @@ func TestFetchWeatherWithContext_NilContext(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestFetchWeatherWithContext_Model(t *testing.T) {
+	tests := []struct {
+		model string
+		want  string
+	}{
+		{"", ""},
+		{"auto", ""},
+		{"ecmwf", "ecmwf_ifs025"},
+		{"ICON", "icon_seamless"},
+		{"ukv", "ukmo_uk_deterministic_2km"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			var got string
+			client := mockClient(func(req *http.Request) (*http.Response, error) {
+				got = req.URL.Query().Get("models")
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"hourly":{"time":[]}}`))),
+					Header:     make(http.Header),
+				}, nil
+			})
+			_, err := FetchWeatherWithContext(context.Background(), Site{}, ForecastOptions{Model: tt.model, HTTPClient: client})
+			if err != nil {
+				t.Fatalf("FetchWeatherWithContext: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("models = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchWeatherWithContext_UnknownModel(t *testing.T) {
+	client := mockClient(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("no request should be made for an unknown model")
+		return nil, nil
+	})
+	_, err := FetchWeatherWithContext(context.Background(), Site{}, ForecastOptions{Model: "nam", HTTPClient: client})
+	if !errors.Is(err, ErrUnknownModel) {
+		t.Fatalf("expected ErrUnknownModel, got: %v", err)
+	}
+}
+
+func TestGenerateForecastWithContext_Cancellation(t *testing.T) {
+	client := mockClient(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := GenerateForecastWithContext(ctx, Site{Name: "Test"}, ForecastOptions{HTTPClient: client})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+}
+
+func TestParseOpenMeteoBatchJSON(t *testing.T) {
+	raw := `[
+		{"latitude": 50.6, "hourly": {"time": ["2026-02-19T08:00"], "wind_speed_10m": [12.0]}},
+		{"latitude": 50.9, "hourly": {"time": ["2026-02-19T08:00"], "wind_speed_10m": [20.0]}}
+	]`
+	data, err := ParseOpenMeteoBatchJSON([]byte(raw))
+	if err != nil {
+		t.Fatalf("ParseOpenMeteoBatchJSON: %v", err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("got %d locations, want 2", len(data))
+	}
+	if data[0][0].WindSpeed != 12 || data[1][0].WindSpeed != 20 {
+		t.Errorf("wind speeds = %v, %v; want 12, 20", data[0][0].WindSpeed, data[1][0].WindSpeed)
+	}
+
+	// A single-location response is a bare object.
+	single, err := ParseOpenMeteoBatchJSON([]byte(`{"hourly": {"time": ["2026-02-19T08:00"]}}`))
+	if err != nil || len(single) != 1 {
+		t.Fatalf("single object: len=%d err=%v", len(single), err)
+	}
+}
+
+func TestParseOpenMeteoJSON_SoundingLevels(t *testing.T) {
+	raw := `{
+		"hourly": {
+			"time": ["2026-02-19T12:00"],
+			"wind_speed_875hPa": [18.0],
+			"temperature_875hPa": [4.0],
+			"geopotential_height_875hPa": [1250.0],
+			"relative_humidity_875hPa": [65.0],
+			"dew_point_875hPa": [-2.0],
+			"cloud_cover_875hPa": [20.0],
+			"vertical_velocity_875hPa": [0.4],
+			"wind_speed_600hPa": [null],
+			"geopotential_height_600hPa": [null]
+		}
+	}`
+	data, err := ParseOpenMeteoJSON([]byte(raw))
+	if err != nil {
+		t.Fatalf("ParseOpenMeteoJSON: %v", err)
+	}
+	levels := data[0].PressureLevels
+	if len(levels) != 1 {
+		t.Fatalf("pressure levels = %d, want 1 (levels without data skipped)", len(levels))
+	}
+	pl := levels[0]
+	if pl.Pressure != 875 || pl.RelativeHumidity != 65 || pl.DewPoint != -2 || pl.CloudCover != 20 || pl.VerticalVelocity != 0.4 {
+		t.Errorf("875hPa level = %+v", pl)
+	}
+}
+
+func TestParseOpenMeteoJSON_ConvectiveParams(t *testing.T) {
+	raw := `{
+		"hourly": {
+			"time": ["2026-02-19T12:00", "2026-02-19T13:00"],
+			"boundary_layer_height": [1200.0, null],
+			"lifted_index": [-1.5, null],
+			"convective_inhibition": [-80.0, null],
+			"sunshine_duration": [3000.0, null]
+		}
+	}`
+	data, err := ParseOpenMeteoJSON([]byte(raw))
+	if err != nil {
+		t.Fatalf("ParseOpenMeteoJSON: %v", err)
+	}
+	h := data[0]
+	if h.BoundaryLayerHeight != 1200 || h.LiftedIndex != -1.5 || h.CIN != 80 || h.SunshineDuration == nil || *h.SunshineDuration != 3000 {
+		t.Errorf("convective params = BLH %v, LI %v, CIN %v, sun %v", h.BoundaryLayerHeight, h.LiftedIndex, h.CIN, h.SunshineDuration)
+	}
+	// Missing values are neutral zeros, and missing sunshine is nil.
+	if h := data[1]; h.BoundaryLayerHeight != 0 || h.LiftedIndex != 0 || h.CIN != 0 || h.SunshineDuration != nil {
+		t.Errorf("missing params = %+v, want zeros", h)
+	}
+}
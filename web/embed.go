@@ -0,0 +1,81 @@
+// Package web embeds the browser frontend so the pgforecast CLI can serve it.
+//
+// The WASM engine is not checked in; build it before compiling the CLI to
+// include it in the binary:
+//
+//	go generate ./web
+package web
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/matt-FFFFFF/pgforecast"
+)
+
+//go:generate tinygo build -o pgforecast.wasm -target wasm -no-debug ../wasm/
+
+// WASMFile is the name of the compiled WASM engine within Assets.
+const WASMFile = "pgforecast.wasm"
+
+// Assets holds the static frontend. The *wasm* pattern matches wasm_exec.js
+// and, when it has been built, pgforecast.wasm.
+//
+//go:embed index.html *.ico *.png css js *wasm*
+var Assets embed.FS
+
+// HasWASM reports whether the compiled WASM engine was embedded.
+func HasWASM() bool {
+	_, err := fs.Stat(Assets, WASMFile)
+	return err == nil
+}
+
+// Handler serves the embedded frontend. sites.json and tuning.json are
+// generated from the given sites and tuning config rather than read from disk.
+func Handler(sites []pgforecast.Site, tc *pgforecast.TuningConfig) http.Handler {
+	mux := http.NewServeMux()
+	if sites == nil {
+		sites = []pgforecast.Site{}
+	}
+	mux.HandleFunc("GET /sites.json", func(w http.ResponseWriter, r *http.Request) {
+		serveJSON(w, sites)
+	})
+	mux.HandleFunc("GET /tuning.json", func(w http.ResponseWriter, r *http.Request) {
+		serveJSON(w, tc)
+	})
+	mux.Handle("GET /", http.FileServerFS(Assets))
+	return mux
+}
+
+// WriteData writes sites.json and tuning.json into dir, for deployments that
+// serve the frontend as static files.
+func WriteData(dir string, sites []pgforecast.Site, tc *pgforecast.TuningConfig) error {
+	if sites == nil {
+		sites = []pgforecast.Site{}
+	}
+	files := map[string]interface{}{"sites.json": sites, "tuning.json": tc}
+	for name, v := range files {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func serveJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
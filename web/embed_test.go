@@ -0,0 +1,66 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matt-FFFFFF/pgforecast"
+)
+
+func TestHandler(t *testing.T) {
+	sites := []pgforecast.Site{{Name: "Ringstead", Lat: 50.64, Lon: -2.34}}
+	tc := pgforecast.DefaultTuningConfig()
+	tc.Wind.IdealMax = 17
+	h := Handler(sites, tc)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	if rec := get("/"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<html") {
+		t.Errorf("GET / = %d, want index.html", rec.Code)
+	}
+	if rec := get("/wasm_exec.js"); rec.Code != http.StatusOK {
+		t.Errorf("GET /wasm_exec.js = %d", rec.Code)
+	}
+
+	var gotSites []pgforecast.Site
+	if err := json.Unmarshal(get("/sites.json").Body.Bytes(), &gotSites); err != nil {
+		t.Fatalf("decoding sites.json: %v", err)
+	}
+	if len(gotSites) != 1 || gotSites[0].Name != "Ringstead" {
+		t.Errorf("sites.json = %+v", gotSites)
+	}
+
+	var gotTuning pgforecast.TuningConfig
+	if err := json.Unmarshal(get("/tuning.json").Body.Bytes(), &gotTuning); err != nil {
+		t.Fatalf("decoding tuning.json: %v", err)
+	}
+	if gotTuning.Wind.IdealMax != 17 {
+		t.Errorf("tuning.json ideal_max = %v, want 17", gotTuning.Wind.IdealMax)
+	}
+}
+
+func TestWriteData(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteData(dir, nil, pgforecast.DefaultTuningConfig()); err != nil {
+		t.Fatalf("WriteData: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "sites.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(data)) != "[]" {
+		t.Errorf("sites.json = %s, want []", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tuning.json")); err != nil {
+		t.Error(err)
+	}
+}
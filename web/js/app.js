@@ -31,6 +31,9 @@ var defaultTuning = null;
 /** @type {Object|null} Active tuning parameters (possibly user-customised) */
 var activeTuning = null;
 
+/** @type {string} Pilot profile the active tuning was based on ('' for none) */
+var activeProfile = '';
+
 /** @type {string} localStorage key for persisted tuning overrides */
 var TUNING_STORAGE_KEY = 'pgforecast_tuning';
 
@@ -133,6 +136,7 @@ async function init() {
   initMap();
   renderSiteList();
   initWindProfilePopups();
+  initSoundingViewer();
 
   try {
     await loadWasm();
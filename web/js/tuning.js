@@ -4,7 +4,7 @@
  * Provides the tuning overlay UI for adjusting flyability scoring
  * parameters, with import/export and localStorage persistence.
  *
- * Depends on: defaultTuning, activeTuning, TUNING_STORAGE_KEY (app.js),
+ * Depends on: defaultTuning, activeTuning, activeProfile, TUNING_STORAGE_KEY (app.js),
  *             siteForecasts, SITES, selectedSite (app.js),
  *             setStatus, renderSiteList, renderForecast, groupByDay (ui.js),
  *             fetchWeather (weather.js), updateMarkerColor (map.js).
@@ -39,7 +39,45 @@ var TUNING_LABELS = {
     cape_moderate: 'CAPE moderate',
     cape_strong: 'CAPE strong',
     cape_extreme: 'CAPE extreme',
-    lapse_rate_bonus: 'Lapse rate bonus (°C/km)'
+    lapse_rate_bonus: 'Lapse rate bonus (°C/km)',
+    stable_lapse_rate: 'Stable layer lapse rate (°C/km)',
+    inversion_min_c: 'Inversion min strength (°C)',
+    ceiling_weak_ft: 'Ceiling for max Weak (ft)',
+    ceiling_none_ft: 'Ceiling for None (ft)'
+  },
+  thermal_strength: {
+    _title: 'Thermal Strength',
+    sensible_heat_fraction: 'Sensible heat fraction',
+    sink_rate_ms: 'Glider sink rate (m/s)'
+  },
+  convection: {
+    _title: 'Convection',
+    cin_moderate: 'CIN moderate (J/kg)',
+    cin_strong: 'CIN strong (J/kg)',
+    li_unstable: 'Lifted index unstable (°C)',
+    li_stable: 'Lifted index stable (°C)',
+    shallow_boundary_layer_m: 'Shallow boundary layer (m)',
+    deep_boundary_layer_m: 'Deep boundary layer (m)',
+    min_sunshine_fraction: 'Min sunshine fraction'
+  },
+  storm: {
+    _title: 'Storm Risk',
+    cape_moderate: 'CAPE moderate risk (J/kg)',
+    cape_high: 'CAPE high risk (J/kg)',
+    li_moderate: 'Lifted index moderate risk (°C)',
+    li_high: 'Lifted index high risk (°C)',
+    mid_level_rh: 'Mid-level humidity (%)',
+    precip_prob: 'Shower probability (%)',
+    shower_lookahead_hours: 'Shower lookahead (hours)',
+    moderate_max_score: 'Max score, moderate risk',
+    high_max_score: 'Max score, high risk'
+  },
+  warnings: {
+    _title: 'Warnings',
+    low_cloudbase_ft: 'Low cloudbase (ft)',
+    low_visibility_m: 'Low visibility (m)',
+    poor_visibility_m: 'Poor visibility (m)',
+    rotor_angle: 'Rotor angle off aspect (°)'
   },
   orographic: {
     _title: 'Orographic Lift',
@@ -50,7 +88,8 @@ var TUNING_LABELS = {
   },
   cloudbase: {
     _title: 'Cloudbase',
-    min_realistic_ft: 'Min realistic (ft)'
+    min_realistic_ft: 'Min realistic (ft)',
+    thermal_trigger_c: 'Thermal trigger (°C)'
   },
   scoring: {
     _title: 'Flyability Scoring',
@@ -108,7 +147,7 @@ async function loadTuning() {
   var stored = localStorage.getItem(TUNING_STORAGE_KEY);
   if (stored) {
     try {
-      activeTuning = JSON.parse(stored);
+      activeTuning = mergeTuning(defaultTuning, JSON.parse(stored));
       updateTuningBadge(true);
     } catch (e) {
       activeTuning = JSON.parse(JSON.stringify(defaultTuning));
@@ -118,6 +157,28 @@ async function loadTuning() {
   }
 }
 
+/**
+ * Deep-merge stored tuning over the defaults, so parameters added since the
+ * tuning was saved pick up their default values.
+ *
+ * @param {Object} defaults - Default tuning object.
+ * @param {Object} overrides - Stored or imported tuning object.
+ * @returns {Object} A new merged tuning object.
+ */
+function mergeTuning(defaults, overrides) {
+  var merged = JSON.parse(JSON.stringify(defaults));
+  for (var key in overrides) {
+    var value = overrides[key];
+    if (value && typeof value === 'object' && !Array.isArray(value) &&
+        merged[key] && typeof merged[key] === 'object') {
+      merged[key] = mergeTuning(merged[key], value);
+    } else {
+      merged[key] = value;
+    }
+  }
+  return merged;
+}
+
 /**
  * Return the active tuning as a JSON string (for passing to WASM).
  * @returns {string} JSON-encoded tuning object.
@@ -309,6 +370,31 @@ function onDisplayInput(element) {
   element.classList.toggle('changed', isChanged);
 }
 
+/**
+ * Total the effect of each tuning key on the selected site's detailed days,
+ * from the score breakdowns in its forecast.
+ *
+ * @returns {Object<string, {total: number, hours: number}>} Effects keyed by "section.key".
+ */
+function scoreEffects() {
+  var effects = {};
+  var forecast = selectedSite && siteForecasts[selectedSite];
+  if (!forecast) return effects;
+
+  forecast.days.slice(0, 3).forEach(function (day) {
+    day.hours.forEach(function (hour) {
+      if (!hour.score_breakdown) return;
+      hour.score_breakdown.contributions.forEach(function (c) {
+        if (!c.key) return;
+        var e = effects[c.key] || (effects[c.key] = { total: 0, hours: 0 });
+        e.total += c.value;
+        e.hours++;
+      });
+    });
+  });
+  return effects;
+}
+
 /**
  * Render the tuning panel HTML with all parameter fields.
  * Each field shows its current value and highlights if changed from default.
@@ -316,6 +402,9 @@ function onDisplayInput(element) {
 function renderTuningPanel() {
   var panel = document.getElementById('tuningPanel');
   var html = '<h2>⚙️ Scoring Parameters <button class="btn-close" onclick="closeTuning()">✕</button></h2>';
+  var effects = scoreEffects();
+  html += '<div class="tuning-problems" id="tuningProblems"></div>';
+  html += renderProfileSection();
 
   for (var section in TUNING_LABELS) {
     var labels = TUNING_LABELS[section];
@@ -334,9 +423,13 @@ function renderTuningPanel() {
       var value = activeTuning[section][key];
       var defaultValue = defaultTuning[section][key];
       var changedClass = (value !== defaultValue) ? 'changed' : '';
+      var effect = effects[section + '.' + key];
 
       html += '<div class="tuning-field">' +
-        '<label title="Default: ' + defaultValue + '">' + labels[key] + '</label>' +
+        '<label title="Default: ' + defaultValue + '">' + labels[key] +
+          (effect ? ' <span class="tuning-effect" title="Total effect on ' + escapeAttr(selectedSite) +
+            ' over ' + effect.hours + ' hours">' + (effect.total >= 0 ? '+' : '') + effect.total.toFixed(1) + ' (' + effect.hours + 'h)</span>' : '') +
+        '</label>' +
         '<input type="number" step="any" value="' + value + '"' +
         ' data-section="' + section + '" data-key="' + key + '"' +
         ' class="' + changedClass + '" onchange="onTuningInput(this)" />' +
@@ -356,6 +449,42 @@ function renderTuningPanel() {
   panel.innerHTML = html;
 }
 
+/**
+ * Render the pilot profile picker. Profiles come from WASM, so the picker is
+ * omitted until it has loaded.
+ * @returns {string} HTML string for the profile section.
+ */
+function renderProfileSection() {
+  if (!wasmReady) return '';
+  var profiles = JSON.parse(pgforecastWasm.pilotProfiles());
+  var html = '<div class="tuning-section"><h3>Pilot Profile</h3>' +
+    '<select class="tuning-profile" onchange="onProfileChange(this)">' +
+    '<option value="">Custom</option>';
+  profiles.forEach(function (p) {
+    html += '<option value="' + escapeAttr(p.name) + '"' + (p.name === activeProfile ? ' selected' : '') +
+      ' title="' + escapeAttr(p.description) + '">' + escapeHTML(p.description) + '</option>';
+  });
+  return html + '</select></div>';
+}
+
+/**
+ * Handle a pilot profile change: rebase the active tuning on the defaults
+ * adjusted for the chosen profile. Applied like any other edit.
+ *
+ * @param {HTMLSelectElement} element - The profile select element.
+ */
+function onProfileChange(element) {
+  activeProfile = element.value;
+  if (!activeProfile) return;
+  var result = JSON.parse(pgforecastWasm.applyProfile(JSON.stringify(defaultTuning), activeProfile));
+  if (result.error) {
+    setStatus('Profile error: ' + result.error);
+    return;
+  }
+  activeTuning = mergeTuning(defaultTuning, result);
+  renderTuningPanel();
+}
+
 /**
  * Handle a tuning input field change.
  * Updates the active tuning value and toggles the "changed" highlight.
@@ -375,10 +504,41 @@ function onTuningInput(element) {
   element.classList.toggle('changed', isChanged);
 }
 
+/**
+ * Check the active tuning with WASM and list any problems at the top of the
+ * panel, highlighting the offending fields.
+ * @returns {boolean} True if the tuning is valid (or WASM is not loaded).
+ */
+function checkTuning() {
+  if (!wasmReady) return true;
+  var problems = JSON.parse(pgforecastWasm.validateTuning(getTuningJSON()));
+  if (problems.error) {
+    problems = [{ path: 'tuning', message: problems.error }];
+  }
+
+  document.querySelectorAll('.tuning-field input.invalid').forEach(function (el) {
+    el.classList.remove('invalid');
+  });
+  problems.forEach(function (p) {
+    var parts = p.path.split('.');
+    var input = document.querySelector('.tuning-field input[data-section="' + parts[0] + '"][data-key="' + parts[1] + '"]');
+    if (input) input.classList.add('invalid');
+  });
+  document.getElementById('tuningProblems').innerHTML = problems.map(function (p) {
+    return '<div>⚠️ ' + escapeHTML(p.path + ': ' + p.message) + '</div>';
+  }).join('');
+  return problems.length === 0;
+}
+
 /**
  * Save current tuning to localStorage and rescore all sites.
+ * Invalid tuning is not applied; its problems are listed in the panel.
  */
 function applyTuning() {
+  if (!checkTuning()) {
+    setStatus('Tuning not applied — fix the highlighted values');
+    return;
+  }
   if (hasCustomTuning()) {
     localStorage.setItem(TUNING_STORAGE_KEY, JSON.stringify(activeTuning));
     updateTuningBadge(true);
@@ -405,6 +565,7 @@ function applyTuning() {
  */
 function resetTuning() {
   activeTuning = JSON.parse(JSON.stringify(defaultTuning));
+  activeProfile = '';
   localStorage.removeItem(TUNING_STORAGE_KEY);
   updateTuningBadge(false);
   renderTuningPanel();
@@ -460,7 +621,7 @@ function importTuning() {
         }
       }
 
-      activeTuning = imported;
+      activeTuning = mergeTuning(defaultTuning, imported);
       renderTuningPanel();
       setStatus('Imported — click Apply');
     } catch (err) {
@@ -50,6 +50,29 @@ function compassDir(degrees) {
   return directions[Math.round(degrees / 22.5) % 16];
 }
 
+/**
+ * Speed-weighted circular mean and standard deviation of wind direction,
+ * so that 350° and 10° average to N rather than S.
+ * @param {Array<Object>} hours - Hourly metrics with wind_direction and wind_speed.
+ * @returns {{mean: number, stdDev: number}} Degrees.
+ */
+function circularDirStats(hours) {
+  var total = hours.reduce(function (sum, h) { return sum + h.wind_speed; }, 0);
+  var weight = function (h) { return total > 0 ? h.wind_speed : 1; };
+  var sinSum = 0, cosSum = 0, wSum = 0;
+  hours.forEach(function (h) {
+    var rad = h.wind_direction * Math.PI / 180;
+    sinSum += weight(h) * Math.sin(rad);
+    cosSum += weight(h) * Math.cos(rad);
+    wSum += weight(h);
+  });
+  var mean = Math.atan2(sinSum, cosSum) * 180 / Math.PI;
+  if (mean < 0) mean += 360;
+  var r = wSum > 0 ? Math.min(Math.hypot(sinSum, cosSum) / wSum, 1) : 0;
+  var stdDev = r > 0 ? Math.min(Math.sqrt(-2 * Math.log(r)) * 180 / Math.PI, 180) : 180;
+  return { mean: mean, stdDev: stdDev };
+}
+
 /**
  * Return a string of star emoji for a 1–5 score.
  * @param {number} count - Number of stars.
@@ -102,6 +125,108 @@ function thermalIcon(rating) {
   return icons[rating] || '❓';
 }
 
+/**
+ * Return an icon flagging overdevelopment risk.
+ * @param {string} risk - Storm risk level (None/Low/Moderate/High).
+ * @returns {string} Emoji icon, or an empty string below Moderate.
+ */
+function stormIcon(risk) {
+  if (risk === 'High') return '⛈';
+  if (risk === 'Moderate') return '🌩';
+  return '';
+}
+
+/**
+ * Render an hour's warnings as icons, with the messages in a tooltip.
+ * @param {Array<Object>} warnings - Warnings ({type, severity, message}) from WASM.
+ * @returns {string} HTML string for the warnings cell.
+ */
+function warningsHTML(warnings) {
+  if (!warnings || warnings.length === 0) return '';
+  var icons = {
+    gusts: '💨',
+    wind: '🌬',
+    wind_aloft: '🎈',
+    gradient: '📶',
+    direction: '🧭',
+    rotor: '🌀',
+    rain: '🌧',
+    cloudbase: '🌫',
+    visibility: '👁',
+    storm: '⛈'
+  };
+  var danger = warnings.some(function (w) { return w.severity === 'Danger'; });
+  var title = warnings.map(function (w) {
+    return (w.severity === 'Danger' ? '🔴 ' : '⚠️ ') + w.message;
+  }).join('\n');
+  return '<span class="' + (danger ? 'warning-danger' : 'warning-caution') + '" title="' + escHtml(title) + '">' +
+    warnings.map(function (w) { return icons[w.type] || '⚠️'; }).join('') +
+    '</span>';
+}
+
+/**
+ * Describe how an hour's flyability score was reached, for a tooltip.
+ * @param {Object} breakdown - Score breakdown ({base, contributions, raw, score, capped_by}) from WASM.
+ * @returns {string} One line per contribution, ending with the raw and final score.
+ */
+function scoreBreakdownText(breakdown) {
+  if (!breakdown) return '';
+  var lines = ['Base ' + breakdown.base.toFixed(2)];
+  breakdown.contributions.forEach(function (c) {
+    lines.push((c.value >= 0 ? '+' : '') + c.value.toFixed(2) + ' ' + c.reason);
+  });
+  lines.push('= ' + breakdown.raw.toFixed(2) + ' → ' + breakdown.exact.toFixed(2) + ' → ' + breakdown.score +
+    (breakdown.capped_by ? ' (capped by ' + breakdown.capped_by + ')' : ''));
+  return lines.join('\n');
+}
+
+/**
+ * Describe every launch's score for an hour at a multi-launch site.
+ * @param {Object} hour - Hourly metrics from WASM.
+ * @returns {string} Tooltip lines naming the best launch, or '' for single-launch sites.
+ */
+function launchScoresText(hour) {
+  if (!hour.launch_scores || hour.launch_scores.length < 2) return '';
+  return '\n\nBest launch: ' + hour.launch + '\n' + hour.launch_scores.map(function (ls) {
+    return ls.launch + ' ' + ls.flyability_score_exact.toFixed(2);
+  }).join('\n');
+}
+
+/**
+ * Describe which way a site faces, listing each launch for multi-launch sites.
+ * @param {Object} site - Site from sites.json.
+ * @returns {string} Compass directions, e.g. "NE/W".
+ */
+function siteFacing(site) {
+  if (!site.launches || site.launches.length < 2) return compassDir(site.aspect);
+  return site.launches.map(function (l) { return compassDir(l.aspect); }).join('/');
+}
+
+/**
+ * Find when overdevelopment should be expected: the first hour at Moderate
+ * storm risk or higher, or, when earlier, storm.shower_lookahead_hours before
+ * the first shower in unstable air (mirrors OverdevelopmentOnset).
+ * @param {Array<Object>} hours - Hourly metrics for one day, in time order.
+ * @returns {string|null} Onset time as HH:MM (UTC), or null if none.
+ */
+function overdevelopmentOnset(hours) {
+  var storm = (activeTuning && activeTuning.storm) || {};
+  var lookaheadMs = (storm.shower_lookahead_hours || 0) * 3600000;
+  var onset = null;
+  hours.forEach(function (h) {
+    var t = null;
+    var showery = h.precipitation > 0 || h.precip_probability >= storm.precip_prob;
+    if (['Low', 'Moderate', 'High'].indexOf(h.storm_risk) >= 0 && showery) {
+      t = Math.max(new Date(h.time).getTime() - lookaheadMs, new Date(hours[0].time).getTime());
+    } else if (h.storm_risk === 'Moderate' || h.storm_risk === 'High') {
+      t = new Date(h.time).getTime();
+    }
+    if (t !== null && (onset === null || t < onset)) onset = t;
+  });
+  if (onset === null) return null;
+  return new Date(onset).getUTCHours().toString().padStart(2, '0') + ':00';
+}
+
 /**
  * Return a cloud cover icon based on percentage.
  * @param {number} cover - Cloud cover percentage (0–100).
@@ -149,9 +274,11 @@ function escHtml(str) {
  */
 var PRESSURE_ALTITUDES = {
   1000: '~100m',
+  975: '~300m',
   950: '~500m',
   925: '~750m',
   900: '~1000m',
+  875: '~1250m',
   850: '~1500m'
 };
 
@@ -255,7 +382,7 @@ function renderSiteList() {
     return '<div class="site-item ' + activeClass + '" data-site="' + escapedName + '">' +
       '<div>' +
         '<div class="site-name">' + escapedName + '</div>' +
-        '<div class="site-meta">' + compassDir(site.aspect) + ' facing · ' + site.elevation + 'm</div>' +
+        '<div class="site-meta">' + siteFacing(site) + ' facing · ' + site.elevation + 'm</div>' +
       '</div>' +
       '<div class="site-score">' + scoreHtml + '</div>' +
     '</div>';
@@ -438,12 +565,17 @@ function renderForecast(forecast) {
       return h.flyability_score;
     }));
     var cloudbase = midHour ? Math.round(midHour.cloudbase_ft || 0) : 0;
+    var onset = overdevelopmentOnset(day.hours);
 
     html += '<div class="day-section">' +
       '<div class="day-header"><span class="day-label">' + label + '</span> ' + dayString + '</div>' +
+      (onset ? '<div class="storm-warning">⛈ Overdevelopment expected after ' + onset + '</div>' : '') +
       '<div class="summary-cards">' +
         '<div class="summary-card"><div class="label">Best Score</div><div class="value">' + starsHTML(bestScore) + '</div></div>' +
-        '<div class="summary-card"><div class="label">Cloudbase</div><div class="value">' + (cloudbase <= 200 ? 'Fog' : cloudbase + 'ft') + '</div></div>' +
+        '<div class="summary-card"><div class="label">Cloudbase</div><div class="value">' + (cloudbase <= 200 ? 'Fog' : cloudbase + 'ft') + '</div>' +
+          (midHour && midHour.cloudbase_amsl_ft ? '<div class="label">' + midHour.cloudbase_amsl_ft + 'ft AMSL · thermals to ' + midHour.thermal_top_ft + 'ft</div>' : '') +
+          (midHour && midHour.inversion_strength_c > 0 ? '<div class="label">' + (midHour.thermals_capped ? '⛔ ' : '') + 'inversion ' + midHour.inversion_base_amsl_ft + 'ft AMSL (+' + midHour.inversion_strength_c.toFixed(1) + '°C)</div>' : '') +
+        '</div>' +
         '<div class="summary-card"><div class="label">CAPE</div><div class="value">' + (midHour ? midHour.cape.toFixed(0) : 0) + ' J/kg</div></div>' +
         '<div class="summary-card"><div class="label">XC Potential</div><div class="value">' + (midHour ? midHour.xc_potential : 'N/A') + '</div></div>' +
       '</div>' +
@@ -453,7 +585,8 @@ function renderForecast(forecast) {
         '<th>Thermal <span class="tooltip-trigger" title="❄️ None · 🌤 Weak · ☀️ Moderate · 🔥 Strong · ⚡ Extreme. Based on CAPE (convective energy) and lapse rate — higher values mean stronger thermals.">❓</span></th>' +
         '<th>Cloud <span class="tooltip-trigger" title="☀️ &lt;20% · ⛅ 20-50% · 🌥 50-80% · ☁️ &gt;80%. Total cloud cover percentage.">❓</span></th>' +
         '<th>Rain <span class="tooltip-trigger" title="🌧 shows actual precipitation (mm). Percentage shows probability of rain when no precipitation detected.">❓</span></th>' +
-        '<th>Score</th></tr>';
+        '<th>Score</th>' +
+        '<th>Warnings <span class="tooltip-trigger" title="Hover the icons for details. 🔴 danger · ⚠️ caution.">❓</span></th></tr>';
 
     day.hours.forEach(function (h) {
       var t = new Date(h.time);
@@ -462,7 +595,7 @@ function renderForecast(forecast) {
       var windColour = windSpeedColour(h.wind_speed);
 
       html += '<tr>' +
-        '<td>' + timeStr + '</td>' +
+        '<td class="sounding-cell" data-time="' + escHtml(h.time) + '" title="Show sounding">' + timeStr + '</td>' +
         '<td style="color:' + windColour + '">' + h.wind_speed.toFixed(0) + '</td>' +
         '<td>' + h.wind_dir_str + '</td>' +
         '<td>' + h.wind_gusts.toFixed(0) + '</td>' +
@@ -471,10 +604,13 @@ function renderForecast(forecast) {
           '(+' + h.wind_gradient_diff.toFixed(0) + ')' +
           buildWindProfilePopup(h) +
         '</td>' +
-        '<td>' + thermalIcon(h.thermal_rating) + ' ' + h.thermal_rating + '</td>' +
+        '<td>' + thermalIcon(h.thermal_rating) + ' ' + h.thermal_rating +
+          (h.thermal_strength_ms > 0 ? ' <small>' + h.thermal_strength_ms.toFixed(1) + 'm/s</small>' : '') + '</td>' +
         '<td>' + cloudIcon(h.cloud_cover) + '</td>' +
         '<td>' + rainStr(h.precipitation, h.precip_probability) + '</td>' +
-        '<td class="stars">' + starsHTML(h.flyability_score) + '</td>' +
+        '<td class="stars" title="' + escHtml(scoreBreakdownText(h.score_breakdown) + launchScoresText(h)) + '">' + stormIcon(h.storm_risk) + starsHTML(h.flyability_score) +
+          (h.launch_scores && h.launch_scores.length > 1 ? ' <small class="launch-label">' + escHtml(h.launch) + '</small>' : '') + '</td>' +
+        '<td class="warnings-cell">' + warningsHTML(h.warnings) + '</td>' +
       '</tr>';
     });
 
@@ -502,9 +638,7 @@ function renderForecast(forecast) {
         return sum + h.wind_speed;
       }, 0) / day.hours.length;
 
-      var avgDir = day.hours.reduce(function (sum, h) {
-        return sum + h.wind_direction;
-      }, 0) / day.hours.length;
+      var dirStats = circularDirStats(day.hours);
 
       var maxPrecipProb = Math.max.apply(null, day.hours.map(function (h) {
         return h.precip_probability;
@@ -515,8 +649,9 @@ function renderForecast(forecast) {
       }));
       var bestThermal = thermalRanks[bestThermalIndex];
 
+      // Average the unrounded scores, as the Go day summary does.
       var scores = day.hours.map(function (h) {
-        return h.flyability_score;
+        return h.flyability_score_exact !== undefined ? h.flyability_score_exact : h.flyability_score;
       }).sort(function (a, b) { return b - a; });
 
       var topScores = scores.slice(0, 3);
@@ -528,10 +663,10 @@ function renderForecast(forecast) {
       html += '<tr>' +
         '<td>' + dayString + '</td>' +
         '<td>' + avgWind.toFixed(0) + '</td>' +
-        '<td>' + compassDir(avgDir) + '</td>' +
+        '<td>' + compassDir(dirStats.mean) + ' ±' + dirStats.stdDev.toFixed(0) + '°</td>' +
         '<td>' + thermalIcon(bestThermal) + ' ' + bestThermal + '</td>' +
         '<td>' + maxPrecipProb.toFixed(0) + '%</td>' +
-        '<td class="stars">' + starsHTML(avgScore) + '</td>' +
+        '<td class="stars">' + (overdevelopmentOnset(day.hours) ? '⛈' : '') + starsHTML(avgScore) + '</td>' +
       '</tr>';
     });
 
@@ -614,3 +749,60 @@ function initWindProfilePopups() {
     }
   });
 }
+
+/**
+ * Show the skew-T sounding for an hour of the selected site, rendered by WASM
+ * from the cached weather response.
+ *
+ * @param {string} time - ISO timestamp of the hour (from the metrics).
+ * @param {boolean} [emagram] - Draw an emagram instead of a skew-T.
+ */
+function showSounding(time, emagram) {
+  var forecast = siteForecasts[selectedSite];
+  if (!forecast || !forecast._weatherJSON || !wasmReady) return;
+
+  var result = JSON.parse(pgforecastWasm.renderSounding(
+    forecast._weatherJSON, JSON.stringify(forecast.site), time, getTuningJSON(), !!emagram
+  ));
+
+  var panel = document.getElementById('soundingPanel');
+  var t = new Date(time);
+  var title = escHtml(forecast.site.name) + ' ' +
+    t.toLocaleDateString('en-GB', { weekday: 'short', day: 'numeric', month: 'short', timeZone: 'UTC' }) +
+    ' ' + t.getUTCHours().toString().padStart(2, '0') + ':00';
+
+  panel.innerHTML =
+    '<h2>' + title +
+      '<span>' +
+        '<button class="header-btn" id="soundingToggle">' + (emagram ? 'Skew-T' : 'Emagram') + '</button> ' +
+        '<button class="header-btn" onclick="closeSounding()">✕</button>' +
+      '</span>' +
+    '</h2>' +
+    (result.error
+      ? '<p style="color:var(--bad);">' + escHtml(result.error) + '</p>'
+      : result.svg);
+
+  document.getElementById('soundingToggle').onclick = function () {
+    showSounding(time, !emagram);
+  };
+  document.getElementById('soundingOverlay').classList.add('open');
+}
+
+/** Close the sounding viewer. */
+function closeSounding() {
+  document.getElementById('soundingOverlay').classList.remove('open');
+}
+
+/**
+ * Open the sounding viewer when an hour's time cell is clicked.
+ * Called from app.js init() after DOM is ready.
+ */
+function initSoundingViewer() {
+  var panel = document.getElementById('forecastPanel');
+  if (!panel) return;
+
+  panel.addEventListener('click', function (e) {
+    var cell = e.target.closest('.sounding-cell');
+    if (cell) showSounding(cell.dataset.time);
+  });
+}
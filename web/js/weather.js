@@ -5,7 +5,7 @@
  */
 
 /** @type {number[]} Pressure levels to request from Open-Meteo */
-var PRESSURE_LEVELS = [1000, 950, 925, 900, 850, 700];
+var PRESSURE_LEVELS = [1000, 975, 950, 925, 900, 875, 850, 800, 750, 700, 650, 600, 500];
 
 /** @type {string[]} Surface-level parameters to request */
 var SURFACE_PARAMS = [
@@ -27,7 +27,11 @@ var SURFACE_PARAMS = [
   'is_day',
   'weather_code',
   'pressure_msl',
-  'visibility'
+  'visibility',
+  'boundary_layer_height',
+  'lifted_index',
+  'convective_inhibition',
+  'sunshine_duration'
 ];
 
 /**
@@ -44,7 +48,11 @@ function buildOpenMeteoURL(lat, lon) {
       'wind_speed_' + p + 'hPa',
       'wind_direction_' + p + 'hPa',
       'temperature_' + p + 'hPa',
-      'geopotential_height_' + p + 'hPa'
+      'geopotential_height_' + p + 'hPa',
+      'relative_humidity_' + p + 'hPa',
+      'dew_point_' + p + 'hPa',
+      'cloud_cover_' + p + 'hPa',
+      'vertical_velocity_' + p + 'hPa'
     ];
   });
 